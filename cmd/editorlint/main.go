@@ -1,9 +1,17 @@
 package main
 
 import (
+  "context"
+  "errors"
   "fmt"
   "os"
+  "os/signal"
+  "strings"
+  "syscall"
+  "time"
 
+  "github.com/dobbo-ca/editorlint/pkg/output"
+  "github.com/dobbo-ca/editorlint/pkg/rules"
   "github.com/dobbo-ca/editorlint/pkg/validator"
   "github.com/spf13/cobra"
 )
@@ -13,46 +21,228 @@ var (
   fixFlag        bool
   configFlag     string
   outputFlag     string
+  outputFileFlag string
+  maxEntriesFlag int
+  templateFlag   string
+  templateFile   string
   workersFlag    int
   quietFlag      bool
   excludeFlag    []string
+  enableFlag     []string
+  disableFlag    []string
+  reportFlag     []string
+  timeoutFlag    time.Duration
+  keepGoingFlag  bool
+  failFastFlag   bool
+  listRulesFlag  bool
 )
 
 var rootCmd = &cobra.Command{
-  Use:   "editorlint [directory|file]",
+  Use:   "editorlint [directory|file]...",
   Short: "A tool to validate files against .editorconfig rules",
   Long:  "editorlint reads .editorconfig files and validates that all files in a repository follow the specified configuration rules.",
-  Args:  cobra.ExactArgs(1),
+  Args: func(cmd *cobra.Command, args []string) error {
+    // Listing the rules is the only mode that needs no target
+    if listRulesFlag {
+      return nil
+    }
+    return cobra.MinimumNArgs(1)(cmd, args)
+  },
   Run: func(cmd *cobra.Command, args []string) {
-    target := args[0]
+    if listRulesFlag {
+      listRules(os.Stdout)
+      return
+    }
+
+    // Reject unknown rules before anything is written
+    if _, err := rules.Select(enableFlag, disableFlag); err != nil {
+      fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+      os.Exit(1)
+    }
+
+    // Load the output template
+    tmpl := templateFlag
+    if templateFile != "" {
+      data, err := os.ReadFile(templateFile)
+      if err != nil {
+        fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+        os.Exit(1)
+      }
+      tmpl = string(data)
+    }
+
+    // Open the output files. os.Exit skips deferred calls, so they are
+    // closed explicitly before exiting.
+    var files []*os.File
+    exit := func(err error) {
+      fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+      for _, file := range files {
+        file.Close()
+      }
+      os.Exit(1)
+    }
+    defer func() {
+      for _, file := range files {
+        file.Close()
+      }
+    }()
+
+    // Set up the formatters, each writing to a file or to stdout. Only one
+    // may write to stdout, and progress messages are only printed when they
+    // cannot mix with machine-readable output there.
+    var formatters output.Formatters
+    progress := !quietFlag
+    usesTemplate := false
+    toStdout := false
+    addFormatter := func(format, path string, quiet bool) {
+      if path == "" {
+        if toStdout {
+          exit(fmt.Errorf("only one output can be written to stdout; give --report %s a path with %s=path", format, format))
+        }
+        toStdout = true
+      }
+
+      formatter := output.NewFormatter(format, quiet)
+      if path != "" {
+        file, err := os.Create(path)
+        if err != nil {
+          exit(err)
+        }
+        files = append(files, file)
+        formatter.SetOutput(file)
+      } else if formatter.MachineReadable() {
+        progress = false
+      }
+      if maxEntriesFlag != 0 {
+        formatter.SetMaxEntries(maxEntriesFlag)
+      }
+      if tmpl != "" {
+        if err := formatter.SetTemplate(tmpl); err != nil {
+          exit(fmt.Errorf("invalid output template: %w", err))
+        }
+      }
+      usesTemplate = usesTemplate || format == string(output.FormatTemplate)
+      formatters = append(formatters, formatter)
+    }
+
+    addFormatter(outputFlag, outputFileFlag, quietFlag)
+    for _, spec := range reportFlag {
+      format, path, err := parseReport(spec)
+      if err != nil {
+        exit(err)
+      }
+      // Quiet mode only affects the primary output, so reports are always written in full
+      addFormatter(format, path, false)
+    }
 
-    // Create validator with config
+    if usesTemplate && tmpl == "" {
+      exit(fmt.Errorf("template output requires --template or --template-file"))
+    }
+
+    // Create validator with config, streaming each file's results as it completes
     v := validator.New(validator.Config{
       CustomConfigPath: configFlag,
       Recursive:        recurseFlag,
       Fix:              fixFlag,
-      OutputFormat:     outputFlag,
       Workers:          workersFlag,
-      Quiet:            quietFlag,
       ExcludePatterns:  excludeFlag,
+      EnableRules:      enableFlag,
+      DisableRules:     disableFlag,
+      FailFast:         failFastFlag || !keepGoingFlag,
+      DiscardErrors:    formatters.Streaming(),
+      OnFile: func(file validator.FileResult) {
+        formatters.StreamErrors(file.Errors)
+        if file.Fixed {
+          formatters.StreamFixed(file.Path)
+        }
+        if file.Err != nil {
+          formatters.StreamFileError(output.FileError{FilePath: file.Path, Message: file.Err.Error()})
+        }
+      },
     })
 
-    err := v.ValidateTarget(target)
-    if err != nil {
-      fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-      os.Exit(1)
+    if progress {
+      for _, target := range args {
+        if info, err := os.Stat(target); err == nil {
+          output.FormatProgress(os.Stdout, target, info.IsDir(), fixFlag, recurseFlag)
+        }
+      }
+    }
+
+    // Stop on SIGINT or SIGTERM, or when the timeout expires. A second
+    // signal terminates immediately.
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+    go func() {
+      <-ctx.Done()
+      stop()
+    }()
+    if timeoutFlag > 0 {
+      var cancel context.CancelFunc
+      ctx, cancel = context.WithTimeout(ctx, timeoutFlag)
+      defer cancel()
+    }
+
+    report, err := v.Check(ctx, args)
+    if err != nil && (report == nil || !report.Interrupted && len(report.FileErrors()) == 0) {
+      exit(err)
+    }
+
+    // Interrupted runs, and runs stopped by --fail-fast, still report the
+    // files processed so far
+    result := report.Result()
+    formatters.FormatResults(result)
+
+    switch {
+    case errors.Is(err, context.DeadlineExceeded):
+      exit(fmt.Errorf("timed out after %v", timeoutFlag))
+    case errors.Is(err, context.Canceled):
+      exit(fmt.Errorf("interrupted"))
+    case err != nil:
+      exit(err)
+    }
+
+    if len(result.FileErrors) > 0 {
+      exit(fmt.Errorf("%d files could not be processed", len(result.FileErrors)))
+    }
+
+    if result.Mode == "validate" && result.Violations > 0 {
+      exit(fmt.Errorf("validation failed with %d errors", result.Violations))
     }
   },
 }
 
+// parseReport splits a --report value of the form format[=path]. Without a
+// path the report is written to stdout.
+func parseReport(spec string) (string, string, error) {
+  format, path, _ := strings.Cut(spec, "=")
+  if !output.ValidFormat(format) {
+    return "", "", fmt.Errorf("unknown report format %q in --report %s", format, spec)
+  }
+  return format, path, nil
+}
+
 func init() {
   rootCmd.Flags().BoolVarP(&recurseFlag, "recurse", "r", false, "Scan directories recursively")
   rootCmd.Flags().BoolVarP(&fixFlag, "fix", "f", false, "Automatically fix validation errors")
   rootCmd.Flags().StringVarP(&configFlag, "config", "c", "", "Use specific .editorconfig file instead of searching hierarchy")
-  rootCmd.Flags().StringVarP(&outputFlag, "output", "o", "default", "Output format: default, tabular, json, quiet")
+  rootCmd.Flags().StringVarP(&outputFlag, "output", "o", "default", "Output format: default, tabular, json, sarif, github, junit, checkstyle, gitlab, html, markdown, template, ndjson, quiet")
+  rootCmd.Flags().StringVar(&outputFileFlag, "output-file", "", "Write results to a file instead of standard output")
+  rootCmd.Flags().StringArrayVar(&reportFlag, "report", []string{}, "Also write results as format[=path], to stdout if no path is given and nothing else writes there (can be specified multiple times)")
+  rootCmd.Flags().StringVar(&templateFlag, "template", "", "Go text/template for the template output format")
+  rootCmd.Flags().StringVar(&templateFile, "template-file", "", "File containing a Go text/template for the template output format")
+  rootCmd.MarkFlagsMutuallyExclusive("template", "template-file")
+  rootCmd.Flags().IntVar(&maxEntriesFlag, "max-entries", 0, "Maximum violations listed by the markdown format (0 = default of 100, -1 = unlimited)")
   rootCmd.Flags().IntVarP(&workersFlag, "workers", "w", 0, "Number of parallel workers (0 = auto-detect)")
+  rootCmd.Flags().DurationVar(&timeoutFlag, "timeout", 0, "Stop after this long and report the files processed so far, e.g. 5m (0 = no limit)")
+  rootCmd.Flags().BoolVar(&keepGoingFlag, "keep-going", true, "Keep processing files after one cannot be read or fixed")
+  rootCmd.Flags().BoolVar(&failFastFlag, "fail-fast", false, "Stop at the first file that cannot be read or fixed")
+  rootCmd.MarkFlagsMutuallyExclusive("keep-going", "fail-fast")
   rootCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Quiet mode - minimal output")
   rootCmd.Flags().StringArrayVarP(&excludeFlag, "exclude", "e", []string{}, "Exclude files matching glob patterns (can be specified multiple times)")
+  rootCmd.Flags().StringSliceVar(&enableFlag, "enable", []string{}, "Only run the named rules (comma-separated or repeated; see --list-rules)")
+  rootCmd.Flags().BoolVar(&listRulesFlag, "list-rules", false, "List the available rules and exit")
+  rootCmd.Flags().StringSliceVar(&disableFlag, "disable", []string{}, "Skip the named rules (comma-separated or repeated)")
 }
 
 func main() {
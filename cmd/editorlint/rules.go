@@ -0,0 +1,24 @@
+package main
+
+import (
+  "fmt"
+  "io"
+  "text/tabwriter"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+// listRules writes every registered rule with the EditorConfig property it
+// implements and whether it can be fixed automatically
+func listRules(out io.Writer) {
+  w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+  fmt.Fprintln(w, "NAME\tPROPERTY\tFIXABLE\tDESCRIPTION")
+  for _, rule := range rules.All() {
+    fixable := "no"
+    if rule.Fixable() {
+      fixable = "yes"
+    }
+    fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rule.Name(), rule.Property(), fixable, rule.Description())
+  }
+  w.Flush()
+}
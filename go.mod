@@ -2,9 +2,13 @@ module github.com/dobbo-ca/editorlint
 
 go 1.25.0
 
+require (
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.3
+	golang.org/x/text v0.28.0
+)
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/cobra v1.9.1 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
-	golang.org/x/text v0.28.0 // indirect
 )
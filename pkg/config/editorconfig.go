@@ -4,9 +4,11 @@ package config
 import (
   "bufio"
   "fmt"
+  "io"
+  "io/fs"
   "os"
+  "path"
   "path/filepath"
-  "regexp"
   "strconv"
   "strings"
 )
@@ -28,6 +30,8 @@ type Section struct {
 type ResolvedConfig struct {
   IndentStyle              string
   IndentSize               *int
+  IndentSizeTab            bool
+  IndentSizeTolerance      string
   TabWidth                 *int
   EndOfLine                string
   Charset                  string
@@ -97,6 +101,50 @@ func FindEditorConfigsWithCustomConfig(targetPath, customConfigPath string) ([]*
   return configs, nil
 }
 
+// FindEditorConfigsFS finds the .editorconfig files applying to the file
+// name in fsys, as FindEditorConfigsWithCustomConfig does on disk. Names are
+// slash-separated and the search stops at the root of fsys. If
+// customConfigPath is not empty, it names the only config file in fsys to use.
+func FindEditorConfigsFS(fsys fs.FS, name, customConfigPath string) ([]*EditorConfig, error) {
+  if customConfigPath != "" {
+    config, err := ParseEditorConfigFS(fsys, customConfigPath)
+    if err != nil {
+      return nil, fmt.Errorf("failed to parse custom config %s: %w", customConfigPath, err)
+    }
+
+    return []*EditorConfig{config}, nil
+  }
+
+  var configs []*EditorConfig
+
+  dir := path.Dir(name)
+  for {
+    configPath := path.Join(dir, ".editorconfig")
+
+    if _, err := fs.Stat(fsys, configPath); err == nil {
+      config, err := ParseEditorConfigFS(fsys, configPath)
+      if err != nil {
+        return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+      }
+
+      configs = append([]*EditorConfig{config}, configs...) // Prepend to maintain parent->child order
+
+      if config.Root {
+        break
+      }
+    }
+
+    parent := path.Dir(dir)
+    if parent == dir {
+      // Reached the root of the file system
+      break
+    }
+    dir = parent
+  }
+
+  return configs, nil
+}
+
 // ParseEditorConfig parses a single .editorconfig file
 func ParseEditorConfig(filePath string) (*EditorConfig, error) {
   file, err := os.Open(filePath)
@@ -105,8 +153,24 @@ func ParseEditorConfig(filePath string) (*EditorConfig, error) {
   }
   defer file.Close()
 
+  return parseEditorConfig(file, filePath)
+}
+
+// ParseEditorConfigFS parses a single .editorconfig file from fsys
+func ParseEditorConfigFS(fsys fs.FS, name string) (*EditorConfig, error) {
+  file, err := fsys.Open(name)
+  if err != nil {
+    return nil, err
+  }
+  defer file.Close()
+
+  return parseEditorConfig(file, name)
+}
+
+// parseEditorConfig parses .editorconfig content read from r
+func parseEditorConfig(r io.Reader, filePath string) (*EditorConfig, error) {
   config := &EditorConfig{FilePath: filePath}
-  scanner := bufio.NewScanner(file)
+  scanner := bufio.NewScanner(r)
 
   var currentSection *Section
   inHeaderSection := true
@@ -189,53 +253,12 @@ func matchesPattern(filePath, pattern, configPath string) (bool, error) {
   // Normalize path separators to forward slashes
   relPath = filepath.ToSlash(relPath)
 
-  // Convert editorconfig pattern to regex
-  regexPattern, err := ConvertPatternToRegex(pattern)
+  glob, err := CompileGlob(pattern)
   if err != nil {
     return false, err
   }
 
-  matched, err := regexp.MatchString(regexPattern, relPath)
-  return matched, err
-}
-
-// ConvertPatternToRegex converts an editorconfig glob pattern to a regex
-func ConvertPatternToRegex(pattern string) (string, error) {
-  // Escape regex special characters except our glob characters
-  pattern = regexp.QuoteMeta(pattern)
-
-  // Convert escaped glob patterns back to regex equivalents
-  pattern = strings.ReplaceAll(pattern, "\\*\\*", ".*")     // ** matches anything including path separators
-
-  // Special case: if pattern is just "*", treat it like "**" for compatibility
-  // This matches common EditorConfig usage where [*] is expected to match all files
-  if pattern == "\\*" {
-    pattern = ".*"
-  } else {
-    pattern = strings.ReplaceAll(pattern, "\\*", "[^/]*")    // * matches anything except path separators
-  }
-  pattern = strings.ReplaceAll(pattern, "\\?", ".")        // ? matches any single character
-
-  // Handle character classes [abc] and [!abc]
-  pattern = strings.ReplaceAll(pattern, "\\[!", "[^")
-  pattern = strings.ReplaceAll(pattern, "\\[", "[")
-  pattern = strings.ReplaceAll(pattern, "\\]", "]")
-
-  // Handle brace expansion {js,ts,jsx}
-  braceRegex := regexp.MustCompile(`\\{([^}]+)\\}`)
-  pattern = braceRegex.ReplaceAllStringFunc(pattern, func(match string) string {
-    // Remove escaped braces
-    content := match[2 : len(match)-2]
-    // Split by comma and create alternation
-    parts := strings.Split(content, ",")
-    for i, part := range parts {
-      parts[i] = regexp.QuoteMeta(part)
-    }
-    return "(" + strings.Join(parts, "|") + ")"
-  })
-
-  // Anchor the pattern to match the full path
-  return "^" + pattern + "$", nil
+  return glob.Match(relPath), nil
 }
 
 // applyProperties applies properties to a ResolvedConfig
@@ -250,8 +273,16 @@ func applyProperties(config *ResolvedConfig, properties map[string]string) {
       if value == "tab" {
         // Use tab_width value if available
         config.IndentSize = nil
+        config.IndentSizeTab = true
       } else if size, err := strconv.Atoi(value); err == nil && size > 0 {
         config.IndentSize = &size
+        config.IndentSizeTab = false
+      }
+    case "indent_size_tolerance":
+      // Non-standard editorlint extension: "continuation" accepts
+      // off-grid indentation on continuation and alignment lines
+      if value == "strict" || value == "continuation" {
+        config.IndentSizeTolerance = value
       }
     case "tab_width":
       if width, err := strconv.Atoi(value); err == nil && width > 0 {
@@ -4,6 +4,7 @@ import (
   "os"
   "path/filepath"
   "testing"
+  "testing/fstest"
 )
 
 func TestParseEditorConfig(t *testing.T) {
@@ -105,29 +106,45 @@ indent_style = tab
   }
 }
 
-func TestConvertPatternToRegex(t *testing.T) {
-  tests := []struct {
-    pattern  string
-    expected string
-  }{
-    {"*", "^.*$"},              // * behaves like ** for EditorConfig compatibility
-    {"*.go", "^[^/]*\\.go$"},
-    {"**", "^.*$"},
-    {"src/**", "^src/.*$"},
-    {"*.{js,ts}", "^[^/]*\\.(js|ts)$"},
+func TestResolveConfigForNestedFile(t *testing.T) {
+  tmpDir := t.TempDir()
+  configContent := `root = true
+
+[*.go]
+indent_style = tab
+
+[/vendor/**]
+indent_style = space
+`
+
+  configPath := filepath.Join(tmpDir, ".editorconfig")
+  if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+    t.Fatal(err)
   }
 
-  for _, tt := range tests {
-    t.Run(tt.pattern, func(t *testing.T) {
-      result, err := ConvertPatternToRegex(tt.pattern)
-      if err != nil {
-        t.Fatal(err)
-      }
+  editorConfig, err := ParseEditorConfig(configPath)
+  if err != nil {
+    t.Fatal(err)
+  }
 
-      if result != tt.expected {
-        t.Errorf("Expected %s, got %s", tt.expected, result)
-      }
-    })
+  // A pattern without a slash matches at any depth
+  resolved, err := ResolveConfigForFile(filepath.Join(tmpDir, "sub", "pkg", "file.go"), []*EditorConfig{editorConfig})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if resolved.IndentStyle != "tab" {
+    t.Errorf("Expected indent_style = tab for nested .go file, got %s", resolved.IndentStyle)
+  }
+
+  // Later sections override earlier ones
+  resolved, err = ResolveConfigForFile(filepath.Join(tmpDir, "vendor", "lib", "file.go"), []*EditorConfig{editorConfig})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if resolved.IndentStyle != "space" {
+    t.Errorf("Expected indent_style = space for vendored file, got %s", resolved.IndentStyle)
   }
 }
 
@@ -174,3 +191,55 @@ indent_style = tab
     t.Error("Expected trim_trailing_whitespace = true")
   }
 }
+
+func TestFindEditorConfigsFS(t *testing.T) {
+  fsys := fstest.MapFS{
+    ".editorconfig":        {Data: []byte("root = true\n\n[*]\nindent_style = space\nindent_size = 2\n")},
+    "src/.editorconfig":    {Data: []byte("[*.go]\nindent_style = tab\n")},
+    "vendor/.editorconfig": {Data: []byte("root = true\n\n[*]\nindent_style = tab\n")},
+    "custom.editorconfig":  {Data: []byte("[*]\nend_of_line = crlf\n")},
+    "src/main.go":          {Data: []byte("package main\n")},
+  }
+
+  tests := []struct {
+    name        string
+    file        string
+    custom      string
+    configs     int
+    indentStyle string
+    endOfLine   string
+  }{
+    {"root directory", "README.md", "", 1, "space", ""},
+    {"child overrides parent", "src/main.go", "", 2, "tab", ""},
+    {"child pattern does not match", "src/notes.txt", "", 2, "space", ""},
+    {"nested root stops search", "vendor/lib/a.txt", "", 1, "tab", ""},
+    {"custom config", "src/main.go", "custom.editorconfig", 1, "", "crlf"},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      configs, err := FindEditorConfigsFS(fsys, tt.file, tt.custom)
+      if err != nil {
+        t.Fatal(err)
+      }
+      if len(configs) != tt.configs {
+        t.Fatalf("Expected %d configs, got %d", tt.configs, len(configs))
+      }
+
+      resolved, err := ResolveConfigForFile(tt.file, configs)
+      if err != nil {
+        t.Fatal(err)
+      }
+      if resolved.IndentStyle != tt.indentStyle {
+        t.Errorf("Expected indent_style = %q, got %q", tt.indentStyle, resolved.IndentStyle)
+      }
+      if resolved.EndOfLine != tt.endOfLine {
+        t.Errorf("Expected end_of_line = %q, got %q", tt.endOfLine, resolved.EndOfLine)
+      }
+    })
+  }
+
+  if _, err := FindEditorConfigsFS(fsys, "src/main.go", "missing.editorconfig"); err == nil {
+    t.Error("Expected an error for a missing custom config")
+  }
+}
@@ -0,0 +1,310 @@
+package config
+
+import (
+  "fmt"
+  "regexp"
+  "strconv"
+  "strings"
+  "sync"
+)
+
+// Glob is a compiled EditorConfig section pattern.
+//
+// Matching follows the EditorConfig specification:
+//   - *         any string of characters except /
+//   - **        any string of characters, including /
+//   - ?         any single character except /
+//   - [name]    any single character in name, [!name] any character not in name
+//   - {s1,s2}   any of the comma-separated strings, which may themselves be globs
+//   - {n1..n2}  any integer between n1 and n2 inclusive
+//   - \c        the literal character c
+//
+// A pattern without a / matches files at any depth below the .editorconfig.
+// A pattern containing a / is matched relative to the .editorconfig directory,
+// and a leading / only serves to anchor it there.
+type Glob struct {
+  pattern string
+  re      *regexp.Regexp
+  ranges  []numericRange
+}
+
+// numericRange is the inclusive bound of a {n1..n2} expression
+type numericRange struct {
+  min, max int
+}
+
+var numericRangePattern = regexp.MustCompile(`^\{(-?\d+)\.\.(-?\d+)\}`)
+
+// globCache holds compiled globs keyed by pattern, since the same section
+// patterns are matched against every file in a run
+var globCache sync.Map
+
+// CompileGlob compiles an EditorConfig glob pattern.
+func CompileGlob(pattern string) (*Glob, error) {
+  if cached, ok := globCache.Load(pattern); ok {
+    return cached.(*Glob), nil
+  }
+
+  p := &globParser{
+    pattern: []rune(pattern),
+    braces:  bracesBalanced(pattern),
+  }
+
+  var prefix string
+  switch {
+  case !strings.Contains(pattern, "/"):
+    // No separator: match the basename at any depth
+    prefix = "^(?:.*/)?"
+  case strings.HasPrefix(pattern, "/"):
+    prefix = "^"
+    p.pos = 1
+  default:
+    prefix = "^"
+  }
+
+  // A leading **/ also matches files directly in the .editorconfig directory
+  if strings.HasPrefix(string(p.pattern[p.pos:]), "**/") {
+    prefix += "(?:.*/)?"
+    p.pos += 3
+  }
+
+  expr := prefix + p.parse(0) + "$"
+
+  re, err := regexp.Compile(expr)
+  if err != nil {
+    return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+  }
+
+  g := &Glob{pattern: pattern, re: re, ranges: p.ranges}
+  globCache.Store(pattern, g)
+  return g, nil
+}
+
+// Match reports whether path matches the glob. The path must use forward
+// slashes and be relative to the directory containing the .editorconfig.
+func (g *Glob) Match(path string) bool {
+  if len(g.ranges) == 0 {
+    return g.re.MatchString(path)
+  }
+
+  groups := g.re.FindStringSubmatch(path)
+  if groups == nil {
+    return false
+  }
+
+  // Each numeric range is captured by its own group, in order
+  for i, r := range g.ranges {
+    value := groups[i+1]
+    if value == "" {
+      continue // Range was inside an alternative that did not participate
+    }
+    n, err := strconv.Atoi(value)
+    if err != nil || n < r.min || n > r.max {
+      return false
+    }
+  }
+
+  return true
+}
+
+// String returns the source pattern.
+func (g *Glob) String() string {
+  return g.pattern
+}
+
+// globParser translates an EditorConfig glob into a regular expression
+type globParser struct {
+  pattern []rune
+  pos     int
+  braces  bool // Whether braces are balanced and act as alternation
+  ranges  []numericRange
+}
+
+// parse translates the pattern from the current position. Inside braces
+// (depth > 0) it stops before a top-level , or } and leaves it to the caller.
+func (p *globParser) parse(depth int) string {
+  var out strings.Builder
+
+  for p.pos < len(p.pattern) {
+    c := p.pattern[p.pos]
+
+    switch c {
+    case '\\':
+      if p.pos+1 < len(p.pattern) {
+        out.WriteString(regexp.QuoteMeta(string(p.pattern[p.pos+1])))
+        p.pos += 2
+      } else {
+        out.WriteString(`\\`)
+        p.pos++
+      }
+    case '*':
+      if p.pos+1 < len(p.pattern) && p.pattern[p.pos+1] == '*' {
+        out.WriteString(".*")
+        p.pos += 2
+      } else {
+        out.WriteString("[^/]*")
+        p.pos++
+      }
+    case '?':
+      out.WriteString("[^/]")
+      p.pos++
+    case '/':
+      // a/**/b also matches a/b
+      if strings.HasPrefix(string(p.pattern[p.pos:]), "/**/") {
+        out.WriteString("(?:/|/.*/)")
+        p.pos += 4
+      } else {
+        out.WriteString("/")
+        p.pos++
+      }
+    case '[':
+      out.WriteString(p.parseBracket())
+    case '{':
+      out.WriteString(p.parseBrace(depth))
+    case ',', '}':
+      if depth > 0 && p.braces {
+        return out.String()
+      }
+      out.WriteString(regexp.QuoteMeta(string(c)))
+      p.pos++
+    default:
+      out.WriteString(regexp.QuoteMeta(string(c)))
+      p.pos++
+    }
+  }
+
+  return out.String()
+}
+
+// parseBrace translates a {...} expression starting at the current position
+func (p *globParser) parseBrace(depth int) string {
+  if !p.braces {
+    p.pos++
+    return `\{`
+  }
+
+  // Numeric range {n1..n2}
+  if m := numericRangePattern.FindStringSubmatch(string(p.pattern[p.pos:])); m != nil {
+    lo, errLo := strconv.Atoi(m[1])
+    hi, errHi := strconv.Atoi(m[2])
+    if errLo == nil && errHi == nil {
+      if lo > hi {
+        lo, hi = hi, lo
+      }
+      p.ranges = append(p.ranges, numericRange{min: lo, max: hi})
+      p.pos += len([]rune(m[0]))
+      // Leading zeros and explicit signs never match
+      return `(-?(?:0|[1-9][0-9]*))`
+    }
+  }
+
+  p.pos++ // Skip {
+
+  var alternatives []string
+  for {
+    alternatives = append(alternatives, p.parse(depth+1))
+    if p.pos >= len(p.pattern) {
+      break
+    }
+    c := p.pattern[p.pos]
+    p.pos++
+    if c == '}' {
+      break
+    }
+  }
+
+  // A single choice such as {word} is matched literally
+  if len(alternatives) == 1 {
+    return `\{` + alternatives[0] + `\}`
+  }
+
+  return "(?:" + strings.Join(alternatives, "|") + ")"
+}
+
+// parseBracket translates a [...] expression starting at the current position.
+// An unterminated bracket, or one containing /, is matched literally.
+func (p *globParser) parseBracket() string {
+  i := p.pos + 1
+  negate := false
+  if i < len(p.pattern) && (p.pattern[i] == '!' || p.pattern[i] == '^') {
+    negate = true
+    i++
+  }
+
+  var class strings.Builder
+  closed := false
+
+  for i < len(p.pattern) {
+    c := p.pattern[i]
+    if c == ']' {
+      closed = true
+      break
+    }
+    if c == '/' {
+      break
+    }
+    if c == '\\' && i+1 < len(p.pattern) {
+      i++
+      c = p.pattern[i]
+    }
+    i++
+
+    // Range such as a-z, unless - is the last character in the class
+    if i+1 < len(p.pattern) && p.pattern[i] == '-' && p.pattern[i+1] != ']' {
+      hi := p.pattern[i+1]
+      i += 2
+      if hi == '\\' && i < len(p.pattern) {
+        hi = p.pattern[i]
+        i++
+      }
+      class.WriteString(quoteClassChar(c) + "-" + quoteClassChar(hi))
+      continue
+    }
+
+    class.WriteString(quoteClassChar(c))
+  }
+
+  if !closed || class.Len() == 0 {
+    p.pos++
+    return `\[`
+  }
+
+  p.pos = i + 1
+  if negate {
+    return "[^/" + class.String() + "]"
+  }
+  return "[" + class.String() + "]"
+}
+
+// quoteClassChar escapes a character for use inside a regex character class
+func quoteClassChar(c rune) string {
+  if strings.ContainsRune(`\]^-[`, c) {
+    return `\` + string(c)
+  }
+  return string(c)
+}
+
+// bracesBalanced reports whether every unescaped { has a matching }.
+// Per the EditorConfig core tests, unbalanced braces are all matched literally.
+func bracesBalanced(pattern string) bool {
+  depth := 0
+  escaped := false
+
+  for _, c := range pattern {
+    switch {
+    case escaped:
+      escaped = false
+    case c == '\\':
+      escaped = true
+    case c == '{':
+      depth++
+    case c == '}':
+      depth--
+      if depth < 0 {
+        return false
+      }
+    }
+  }
+
+  return depth == 0
+}
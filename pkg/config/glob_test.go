@@ -0,0 +1,131 @@
+package config
+
+import (
+  "testing"
+)
+
+// TestGlobMatch mirrors the glob cases from editorconfig-core-test. Paths are
+// relative to the directory containing the .editorconfig.
+func TestGlobMatch(t *testing.T) {
+  tests := []struct {
+    name    string
+    pattern string
+    path    string
+    want    bool
+  }{
+    // star
+    {"star single character", "a*e.c", "ace.c", true},
+    {"star zero characters", "a*e.c", "ae.c", true},
+    {"star multiple characters", "a*e.c", "abcde.c", true},
+    {"star over slash", "a*e.c", "a/e.c", false},
+    {"star after slash", "Bar/*", "Bar/foo.txt", true},
+    {"star after slash not nested", "Bar/*", "Bar/baz/foo.txt", false},
+    {"star matches dot file", "*", ".editorconfig", true},
+    {"star matches at any depth", "*", "sub/dir/file.txt", true},
+    {"extension matches at any depth", "*.go", "sub/pkg/file.go", true},
+    {"extension matches at root", "*.go", "main.go", true},
+    {"extension mismatch", "*.go", "sub/pkg/file.gox", false},
+
+    // question mark
+    {"question single character", "som?.c", "some.c", true},
+    {"question zero characters", "som?.c", "som.c", false},
+    {"question two characters", "som?.c", "something.c", false},
+    {"question over slash", "som?.c", "som/.c", false},
+
+    // brackets
+    {"character choice", "[ab].a", "a.a", true},
+    {"character choice mismatch", "[ab].a", "c.a", false},
+    {"negative character choice", "[!ab].b", "c.b", true},
+    {"negative character choice mismatch", "[!ab].b", "a.b", false},
+    {"character range", "[d-g].c", "f.c", true},
+    {"character range mismatch", "[d-g].c", "h.c", false},
+    {"negative character range", "[!d-g].d", "h.d", true},
+    {"negative character range mismatch", "[!d-g].d", "f.d", false},
+    {"range and choice", "[abd-g].e", "e.e", true},
+    {"range and choice mismatch", "[abd-g].e", "c.e", false},
+    {"choice with dash", "[-ab].f", "-.f", true},
+    {"close bracket inside", "[\\]ab].g", "].g", true},
+    {"close bracket outside", "[ab]].k", "a].k", true},
+    {"negative close bracket inside", "[!\\]ab].i", "c.i", true},
+    {"negative close bracket inside mismatch", "[!\\]ab].i", "].i", false},
+    {"negative close bracket outside", "[!ab]].l", "c].l", true},
+    {"slash inside brackets", "ab[e/]cd.i", "ab[e/]cd.i", true},
+    {"slash inside brackets is literal", "ab[e/]cd.i", "ab/cd.i", false},
+    {"unclosed bracket", "[ab", "[ab", true},
+
+    // braces
+    {"word choice", "*.{py,js,html}", "test.py", true},
+    {"word choice second", "*.{py,js,html}", "test.js", true},
+    {"word choice mismatch", "*.{py,js,html}", "test.pyc", false},
+    {"single choice is literal", "{single}.b", "{single}.b", true},
+    {"single choice does not expand", "{single}.b", "single.b", false},
+    {"empty choice is literal", "{}.c", "{}.c", true},
+    {"choice with empty word", "a{b,c,}.d", "a.d", true},
+    {"choice with empty word b", "a{b,c,}.d", "ab.d", true},
+    {"choice with empty words", "a{,b,,c,}.e", "ac.e", true},
+    {"choice with empty words empty", "a{,b,,c,}.e", "a.e", true},
+    {"no closing brace", "{.f", "{.f", true},
+    {"nested braces word", "{word,{also},this}.g", "word.g", true},
+    {"nested braces literal single", "{word,{also},this}.g", "{also}.g", true},
+    {"nested braces last", "{word,{also},this}.g", "this.g", true},
+    {"nested braces no expansion", "{word,{also},this}.g", "also.g", false},
+    {"nested braces adjacent start", "{{a,b},c}.k", "b.k", true},
+    {"nested braces adjacent end", "{a,{b,c}}.l", "c.l", true},
+    {"closing brace inside", "{},b}.h", "{},b}.h", true},
+    {"missing closing braces", "{{,b,c{d}.i", "{{,b,c{d}.i", true},
+    {"escaped comma", "{a\\,b,cd}.txt", "a,b.txt", true},
+    {"escaped comma alternative", "{a\\,b,cd}.txt", "cd.txt", true},
+    {"escaped comma not split", "{a\\,b,cd}.txt", "a.txt", false},
+    {"escaped closing brace", "{e,\\},f}.txt", "}.txt", true},
+    {"escaped backslash", "{g,\\\\,i}.txt", "\\.txt", true},
+    {"patterns nested in braces", "{some,a{*c,b}[ef]}.j", "abf.j", true},
+    {"patterns nested in braces star", "{some,a{*c,b}[ef]}.j", "acce.j", true},
+    {"patterns nested in braces mismatch", "{some,a{*c,b}[ef]}.j", "abg.j", false},
+    {"numeric range", "{3..120}", "3", true},
+    {"numeric range middle", "{3..120}", "15", true},
+    {"numeric range upper", "{3..120}", "120", true},
+    {"numeric range below", "{3..120}", "1", false},
+    {"numeric range above", "{3..120}", "121", false},
+    {"numeric range leading zero", "{3..120}", "060", false},
+    {"numeric range with suffix", "{3..120}", "5a", false},
+    {"negative numeric range", "file{-5..5}.txt", "file-3.txt", true},
+    {"alphabetical range is literal", "{aardvark..antelope}", "{aardvark..antelope}", true},
+    {"alphabetical range does not expand", "{aardvark..antelope}", "ant", false},
+
+    // star star
+    {"star star over separator", "a**z.c", "a/z.c", true},
+    {"star star over separators", "a**z.c", "am/nz.c", true},
+    {"star star after separator", "b/**z.c", "b/mn/z.c", true},
+    {"star star after separator direct", "b/**z.c", "b/z.c", true},
+    {"star star before separator", "c**/z.c", "cmn/z.c", true},
+    {"star star before separator nested", "c**/z.c", "c/mn/z.c", true},
+    {"star star between separators", "d/**/z.c", "d/z.c", true},
+    {"star star between separators nested", "d/**/z.c", "d/mn/z.c", true},
+    {"star star between separators deep", "d/**/z.c", "d/a/b/z.c", true},
+    {"leading star star", "**/z.c", "z.c", true},
+    {"leading star star nested", "**/z.c", "a/b/z.c", true},
+    {"directory star star", "src/**", "src/a/b.go", true},
+
+    // path separators and anchoring
+    {"separator anchors to root", "a/b.c", "a/b.c", true},
+    {"separator anchors not nested", "a/b.c", "x/a/b.c", false},
+    {"leading slash anchors", "/top.c", "top.c", true},
+    {"leading slash anchors not nested", "/top.c", "sub/top.c", false},
+    {"escaped star is literal", "a\\*.c", "a*.c", true},
+    {"escaped star does not glob", "a\\*.c", "ab.c", false},
+    {"utf-8 characters", "çéñ.txt", "sub/çéñ.txt", true},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      glob, err := CompileGlob(tt.pattern)
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      if got := glob.Match(tt.path); got != tt.want {
+        t.Errorf("CompileGlob(%q).Match(%q) = %v, want %v (regex %s)", tt.pattern, tt.path, got, tt.want, glob.re)
+      }
+    })
+  }
+}
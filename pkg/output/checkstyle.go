@@ -0,0 +1,67 @@
+package output
+
+import (
+  "encoding/xml"
+  "fmt"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+// checkstyleVersion is the checkstyle report format version consumers expect
+const checkstyleVersion = "4.3"
+
+type checkstyleReport struct {
+  XMLName xml.Name         `xml:"checkstyle"`
+  Version string           `xml:"version,attr"`
+  Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+  Name   string            `xml:"name,attr"`
+  Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+  Line     int    `xml:"line,attr,omitempty"`
+  Column   int    `xml:"column,attr,omitempty"`
+  Severity string `xml:"severity,attr"`
+  Message  string `xml:"message,attr"`
+  Source   string `xml:"source,attr"`
+}
+
+// formatCheckstyle outputs results as checkstyle XML, listing every
+// processed file with its violations
+func (f *Formatter) formatCheckstyle(result *Result) {
+  errorsByFile := make(map[string][]rules.ValidationError)
+  files := append([]string(nil), result.Files...)
+  for _, err := range withFileErrors(result) {
+    errorsByFile[err.FilePath] = append(errorsByFile[err.FilePath], err)
+    files = append(files, err.FilePath)
+  }
+
+  report := checkstyleReport{Version: checkstyleVersion}
+  for _, file := range uniqueSorted(files) {
+    entry := checkstyleFile{Name: file}
+    for _, err := range errorsByFile[file] {
+      entry.Errors = append(entry.Errors, checkstyleError{
+        Line:     err.Line,
+        Column:   err.Column,
+        Severity: "error",
+        Message:  err.Message,
+        Source:   checkstyleSource(err.Rule),
+      })
+    }
+    report.Files = append(report.Files, entry)
+  }
+
+  fmt.Fprint(f.out, xml.Header)
+  encoder := xml.NewEncoder(f.out)
+  encoder.Indent("", "  ")
+  encoder.Encode(report)
+  fmt.Fprintln(f.out)
+}
+
+// checkstyleSource maps a rule name to a checkstyle source attribute
+func checkstyleSource(rule string) string {
+  return "editorlint." + rule
+}
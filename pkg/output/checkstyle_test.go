@@ -0,0 +1,43 @@
+package output
+
+import (
+  "bytes"
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestFormatCheckstyle(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("checkstyle", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{
+    Errors: []rules.ValidationError{
+      {FilePath: "b.go", Rule: "max_line_length", Message: `line 2 is 90 columns wide, exceeds "80"`, Line: 2, Column: 81},
+      {FilePath: "c.go", Rule: "file_access", Message: "could not read file"},
+    },
+    Files:      []string{"b.go", "a.go"},
+    TotalFiles: 3,
+    Mode:       "validate",
+  })
+
+  want := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="a.go"></file>
+  <file name="b.go">
+    <error line="2" column="81" severity="error" message="line 2 is 90 columns wide, exceeds &#34;80&#34;" source="editorlint.max_line_length"></error>
+  </file>
+  <file name="c.go">
+    <error severity="error" message="could not read file" source="editorlint.file_access"></error>
+  </file>
+</checkstyle>
+`
+  if got := buf.String(); got != want {
+    t.Errorf("Unexpected output:\n%s\nwant:\n%s", got, want)
+  }
+
+  if strings.Count(buf.String(), "<file ") != 3 {
+    t.Error("Expected every processed file to be listed")
+  }
+}
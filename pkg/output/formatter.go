@@ -4,11 +4,14 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"text/tabwriter"
+	"text/template"
 
 	"github.com/dobbo-ca/editorlint/pkg/rules"
 )
@@ -17,32 +20,60 @@ import (
 type OutputFormat string
 
 const (
-	FormatDefault  OutputFormat = "default"
-	FormatTabular  OutputFormat = "tabular"
-	FormatJSON     OutputFormat = "json"
-	FormatQuiet    OutputFormat = "quiet"
+	FormatDefault     OutputFormat = "default"
+	FormatTabular     OutputFormat = "tabular"
+	FormatJSON        OutputFormat = "json"
+	FormatQuiet       OutputFormat = "quiet"
+	FormatSARIF       OutputFormat = "sarif"
+	FormatGitHub      OutputFormat = "github"
+	FormatJUnit       OutputFormat = "junit"
+	FormatCheckstyle  OutputFormat = "checkstyle"
+	FormatGitLab      OutputFormat = "gitlab"
+	FormatHTML        OutputFormat = "html"
+	FormatMarkdown    OutputFormat = "markdown"
+	FormatTemplate    OutputFormat = "template"
+	FormatNDJSON      OutputFormat = "ndjson"
 )
 
 // Result represents the validation results for output formatting
 type Result struct {
 	Errors      []rules.ValidationError
+	Violations  int // Total violations found; exceeds len(Errors) when streamed violations were discarded
 	FixedFiles  []string
+	Files       []string // Every file that was processed, if known
 	TotalFiles  int
 	Success     bool
 	Mode        string // "validate" or "fix"
+	Rules       []rules.Rule // Rules that were applied; all registered rules if empty
+	Interrupted bool // The run was stopped before every file was processed
+	FileErrors  []FileError // Files that could not be processed
+}
+
+// FileError records a file that could not be processed, such as one that
+// could not be read or written
+type FileError struct {
+	FilePath string
+	Message  string
 }
 
 // Formatter handles different output formats
 type Formatter struct {
-	format OutputFormat
-	quiet  bool
+	format     OutputFormat
+	quiet      bool
+	out        io.Writer
+	maxEntries int
+	template   *template.Template
+	mu         sync.Mutex // Serialises streamed output
+	streamed   bool       // Whether StreamErrors or StreamFixed has been called
 }
 
 // NewFormatter creates a new output formatter
 func NewFormatter(format string, quiet bool) *Formatter {
 	f := &Formatter{
-		format: OutputFormat(format),
-		quiet:  quiet,
+		format:     OutputFormat(format),
+		quiet:      quiet,
+		out:        os.Stdout,
+		maxEntries: defaultMaxEntries,
 	}
 
 	// Override format if quiet mode is enabled
@@ -53,11 +84,113 @@ func NewFormatter(format string, quiet bool) *Formatter {
 	return f
 }
 
+// Formatters writes the same results with several formatters, each to its
+// own writer
+type Formatters []*Formatter
+
+// FormatResults outputs the results with every formatter
+func (fs Formatters) FormatResults(result *Result) {
+	for _, f := range fs {
+		f.FormatResults(result)
+	}
+}
+
+// StreamErrors passes violations to every streaming formatter
+func (fs Formatters) StreamErrors(errs []rules.ValidationError) {
+	for _, f := range fs {
+		f.StreamErrors(errs)
+	}
+}
+
+// StreamFixed passes a fixed file to every streaming formatter
+func (fs Formatters) StreamFixed(path string) {
+	for _, f := range fs {
+		f.StreamFixed(path)
+	}
+}
+
+// Streaming reports whether every formatter is streaming, so violations need
+// not be kept for FormatResults once they have been streamed
+func (fs Formatters) Streaming() bool {
+	for _, f := range fs {
+		if !f.Streaming() {
+			return false
+		}
+	}
+	return len(fs) > 0
+}
+
+// StreamFileError passes a file that could not be processed to every
+// streaming formatter
+func (fs Formatters) StreamFileError(fileErr FileError) {
+	for _, f := range fs {
+		f.StreamFileError(fileErr)
+	}
+}
+
+// FormatProgress writes the line announcing that a target is being checked
+func FormatProgress(w io.Writer, target string, dir, fix, recursive bool) {
+	mode := "Validating"
+	if fix {
+		mode = "Fixing"
+	}
+	if dir {
+		fmt.Fprintf(w, "%s directory: %s (recursive: %v)\n", mode, target, recursive)
+	} else {
+		fmt.Fprintf(w, "%s file: %s\n", mode, target)
+	}
+}
+
+// SetOutput sets the writer results are written to. The default is os.Stdout.
+func (f *Formatter) SetOutput(w io.Writer) {
+	f.out = w
+}
+
+// ValidFormat reports whether name is a supported output format
+func ValidFormat(name string) bool {
+	switch OutputFormat(name) {
+	case FormatDefault, FormatTabular, FormatJSON, FormatQuiet, FormatSARIF, FormatGitHub, FormatJUnit,
+		FormatCheckstyle, FormatGitLab, FormatHTML, FormatMarkdown, FormatTemplate, FormatNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// MachineReadable reports whether the output format is meant for other
+// programs, in which case nothing else should be written alongside it
+func (f *Formatter) MachineReadable() bool {
+	switch f.format {
+	case FormatJSON, FormatSARIF, FormatJUnit, FormatCheckstyle, FormatGitLab, FormatHTML, FormatMarkdown, FormatTemplate, FormatNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
 // FormatResults outputs the validation results in the specified format
 func (f *Formatter) FormatResults(result *Result) {
 	switch f.format {
 	case FormatJSON:
 		f.formatJSON(result)
+	case FormatSARIF:
+		f.formatSARIF(result)
+	case FormatGitHub:
+		f.formatGitHub(result)
+	case FormatJUnit:
+		f.formatJUnit(result)
+	case FormatCheckstyle:
+		f.formatCheckstyle(result)
+	case FormatGitLab:
+		f.formatGitLab(result)
+	case FormatHTML:
+		f.formatHTML(result)
+	case FormatMarkdown:
+		f.formatMarkdown(result)
+	case FormatTemplate:
+		f.formatTemplate(result)
+	case FormatNDJSON:
+		f.formatNDJSON(result)
 	case FormatTabular:
 		f.formatTabular(result)
 	case FormatQuiet:
@@ -67,8 +200,68 @@ func (f *Formatter) FormatResults(result *Result) {
 	}
 }
 
+// interruptedNotice describes an interrupted run, or is empty if the run completed
+func interruptedNotice(result *Result) string {
+	if !result.Interrupted {
+		return ""
+	}
+	return fmt.Sprintf("Interrupted before all files were processed; results cover %d files", result.TotalFiles)
+}
+
+// checkedFiles names the files a passing result covers
+func checkedFiles(result *Result) string {
+	if result.Interrupted || len(result.FileErrors) > 0 {
+		return "All processed files"
+	}
+	return "All files"
+}
+
+// formatInterrupted warns that the results are partial
+func (f *Formatter) formatInterrupted(result *Result) {
+	if notice := interruptedNotice(result); notice != "" {
+		fmt.Fprintf(f.out, "⚠️  %s\n", notice)
+	}
+}
+
+// formatFileErrors lists the files that could not be processed
+func (f *Formatter) formatFileErrors(result *Result) {
+	if len(result.FileErrors) == 0 {
+		return
+	}
+
+	fmt.Fprintf(f.out, "❌ %d files could not be processed:\n", len(result.FileErrors))
+	for _, fileErr := range result.FileErrors {
+		fmt.Fprintf(f.out, "  • %s - %s\n", fileErr.FilePath, fileErr.Message)
+	}
+	fmt.Fprintln(f.out)
+}
+
+// withFileErrors returns the violations followed by an entry for each file
+// that could not be processed, for formats that can only report violations
+func withFileErrors(result *Result) []rules.ValidationError {
+	if len(result.FileErrors) == 0 {
+		return result.Errors
+	}
+
+	errs := append([]rules.ValidationError(nil), result.Errors...)
+	for _, fileErr := range result.FileErrors {
+		errs = append(errs, rules.ValidationError{
+			FilePath: fileErr.FilePath,
+			Rule:     fileAccessRule,
+			Message:  fileErr.Message,
+		})
+	}
+	return errs
+}
+
+// fileAccessRule is the rule name files that could not be processed are
+// reported under by withFileErrors
+const fileAccessRule = "file_access"
+
 // formatDefault outputs in the current default format
 func (f *Formatter) formatDefault(result *Result) {
+	f.formatInterrupted(result)
+	f.formatFileErrors(result)
 	if result.Mode == "fix" {
 		f.formatFixResults(result)
 	} else {
@@ -77,45 +270,71 @@ func (f *Formatter) formatDefault(result *Result) {
 }
 
 func (f *Formatter) formatValidationResults(result *Result) {
-	if result.Success {
-		fmt.Printf("✓ All files pass editorconfig validation\n")
+	if len(result.Errors) == 0 {
+		fmt.Fprintf(f.out, "✓ %s pass editorconfig validation\n", checkedFiles(result))
 		return
 	}
 
 	// Group errors by rule
 	errorsByRule := make(map[string][]rules.ValidationError)
+	var ruleList []string
 	for _, err := range result.Errors {
+		if _, exists := errorsByRule[err.Rule]; !exists {
+			ruleList = append(ruleList, err.Rule)
+		}
 		errorsByRule[err.Rule] = append(errorsByRule[err.Rule], err)
 	}
+	sort.Strings(ruleList)
 
-	fmt.Printf("Found %d validation errors:\n\n", len(result.Errors))
+	fmt.Fprintf(f.out, "Found %d validation errors:\n\n", len(result.Errors))
 
-	for rule, errors := range errorsByRule {
-		fmt.Printf("📋 %s (%d files):\n", rule, len(errors))
+	for _, rule := range ruleList {
+		errors := errorsByRule[rule]
+		fmt.Fprintf(f.out, "📋 %s (%d violations in %d files):\n", rule, len(errors), countFiles(errors))
 		for _, err := range errors {
-			fmt.Printf("  • %s - %s\n", err.FilePath, err.Message)
+			fmt.Fprintf(f.out, "  • %s - %s\n", err.FilePath, err.Message)
 		}
-		fmt.Println()
+		fmt.Fprintln(f.out)
 	}
 
-	fmt.Printf("To fix these errors automatically, run with --fix flag\n")
+	fmt.Fprintf(f.out, "To fix these errors automatically, run with --fix flag\n")
 }
 
 func (f *Formatter) formatFixResults(result *Result) {
 	if len(result.FixedFiles) > 0 {
-		fmt.Printf("✅ Fixed %d files:\n", len(result.FixedFiles))
+		fmt.Fprintf(f.out, "✅ Fixed %d files:\n", len(result.FixedFiles))
 		for _, file := range result.FixedFiles {
-			fmt.Printf("  • %s\n", file)
+			fmt.Fprintf(f.out, "  • %s\n", file)
 		}
 	} else {
-		fmt.Printf("✓ No fixes needed - all files already pass editorconfig validation\n")
+		fmt.Fprintf(f.out, "✓ No fixes needed - all files already pass editorconfig validation\n")
+	}
+}
+
+// lastLine returns the last line a violation covers. A span ending at the
+// start of a line, such as a line ending, does not cover that line.
+func lastLine(err rules.ValidationError) int {
+	if err.EndColumn == 1 && err.EndLine > err.Line {
+		return err.EndLine - 1
 	}
+	return err.EndLine
+}
+
+// countFiles returns the number of distinct files among errors
+func countFiles(errors []rules.ValidationError) int {
+	files := make(map[string]bool)
+	for _, err := range errors {
+		files[err.FilePath] = true
+	}
+	return len(files)
 }
 
 // formatTabular outputs results in a table format
 func (f *Formatter) formatTabular(result *Result) {
-	if result.Success {
-		fmt.Printf("✓ All files pass editorconfig validation\n")
+	f.formatInterrupted(result)
+	f.formatFileErrors(result)
+	if len(result.Errors) == 0 && len(result.FixedFiles) == 0 {
+		fmt.Fprintf(f.out, "✓ %s pass editorconfig validation\n", checkedFiles(result))
 		return
 	}
 
@@ -141,7 +360,7 @@ func (f *Formatter) formatTabular(result *Result) {
 	sort.Strings(ruleList)
 
 	// Create table
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	w := tabwriter.NewWriter(f.out, 0, 0, 2, ' ', 0)
 
 	// Header
 	header := []string{"File"}
@@ -170,16 +389,16 @@ func (f *Formatter) formatTabular(result *Result) {
 		row := []string{displayPath}
 		fileErrors := errorsByFile[file]
 
-		// Create map of rules for this file
-		fileRules := make(map[string]string)
+		// Count violations of each rule for this file
+		fileRules := make(map[string]int)
 		for _, err := range fileErrors {
-			fileRules[err.Rule] = "❌"
+			fileRules[err.Rule]++
 		}
 
 		// Add status for each rule
 		for _, rule := range ruleList {
-			if status, exists := fileRules[rule]; exists {
-				row = append(row, status)
+			if count, exists := fileRules[rule]; exists {
+				row = append(row, fmt.Sprintf("❌ %d", count))
 			} else {
 				row = append(row, "✅")
 			}
@@ -189,7 +408,7 @@ func (f *Formatter) formatTabular(result *Result) {
 	}
 
 	w.Flush()
-	fmt.Printf("\nFound %d validation errors in %d files\n", len(result.Errors), len(errorsByFile))
+	fmt.Fprintf(f.out, "\nFound %d validation errors in %d files\n", len(result.Errors), len(errorsByFile))
 }
 
 // formatPathsForTable optimizes file paths for tabular display
@@ -279,57 +498,111 @@ func (f *Formatter) shortenPaths(paths []string, maxWidth int) []string {
 	return shortened
 }
 
-// formatJSON outputs results in JSON format
-func (f *Formatter) formatJSON(result *Result) {
-	type jsonError struct {
-		FilePath string `json:"file_path"`
-		Rule     string `json:"rule"`
-		Message  string `json:"message"`
+// jsonEdit is the JSON form of a rules.TextEdit
+type jsonEdit struct {
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"end_line"`
+	EndColumn int    `json:"end_column"`
+	Offset    int    `json:"offset"`
+	Length    int    `json:"length"`
+	NewText   string `json:"new_text"`
+}
+
+// jsonError is the JSON form of a rules.ValidationError
+type jsonError struct {
+	FilePath  string     `json:"file_path"`
+	Rule      string     `json:"rule"`
+	Message   string     `json:"message"`
+	Line      int        `json:"line,omitempty"`
+	Column    int        `json:"column,omitempty"`
+	EndLine   int        `json:"end_line,omitempty"`
+	EndColumn int        `json:"end_column,omitempty"`
+	Offset    *int       `json:"offset,omitempty"`
+	Length    *int       `json:"length,omitempty"`
+	Edits     []jsonEdit `json:"edits,omitempty"`
+}
+
+// jsonFileError is the JSON form of a FileError
+type jsonFileError struct {
+	FilePath string `json:"file_path"`
+	Message  string `json:"message"`
+}
+
+// newJSONError converts a violation to its JSON form
+func newJSONError(err rules.ValidationError) jsonError {
+	result := jsonError{
+		FilePath:  err.FilePath,
+		Rule:      err.Rule,
+		Message:   err.Message,
+		Line:      err.Line,
+		Column:    err.Column,
+		EndLine:   err.EndLine,
+		EndColumn: err.EndColumn,
+	}
+	// Offset 0 is meaningful, so only omit the span when there is no position
+	if err.Line > 0 {
+		offset, length := err.Offset, err.Length
+		result.Offset = &offset
+		result.Length = &length
 	}
+	for _, edit := range err.Edits {
+		result.Edits = append(result.Edits, jsonEdit(edit))
+	}
+	return result
+}
 
+// formatJSON outputs results in JSON format
+func (f *Formatter) formatJSON(result *Result) {
 	type jsonResult struct {
-		Success    bool        `json:"success"`
-		Mode       string      `json:"mode"`
-		TotalFiles int         `json:"total_files"`
-		Errors     []jsonError `json:"errors,omitempty"`
-		FixedFiles []string    `json:"fixed_files,omitempty"`
+		Success     bool            `json:"success"`
+		Mode        string          `json:"mode"`
+		Interrupted bool            `json:"interrupted,omitempty"`
+		TotalFiles  int             `json:"total_files"`
+		Errors      []jsonError     `json:"errors,omitempty"`
+		FixedFiles  []string        `json:"fixed_files,omitempty"`
+		FileErrors  []jsonFileError `json:"file_errors,omitempty"`
 	}
 
 	jsonErrors := make([]jsonError, len(result.Errors))
 	for i, err := range result.Errors {
-		jsonErrors[i] = jsonError{
-			FilePath: err.FilePath,
-			Rule:     err.Rule,
-			Message:  err.Message,
-		}
+		jsonErrors[i] = newJSONError(err)
 	}
 
 	output := jsonResult{
-		Success:    result.Success,
-		Mode:       result.Mode,
-		TotalFiles: result.TotalFiles,
-		Errors:     jsonErrors,
-		FixedFiles: result.FixedFiles,
+		Success:     result.Success,
+		Mode:        result.Mode,
+		Interrupted: result.Interrupted,
+		TotalFiles:  result.TotalFiles,
+		Errors:      jsonErrors,
+		FixedFiles:  result.FixedFiles,
+	}
+	for _, fileErr := range result.FileErrors {
+		output.FileErrors = append(output.FileErrors, jsonFileError(fileErr))
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
+	encoder := json.NewEncoder(f.out)
 	encoder.SetIndent("", "  ")
 	encoder.Encode(output)
 }
 
 // formatQuiet outputs minimal results
 func (f *Formatter) formatQuiet(result *Result) {
+	f.formatInterrupted(result)
+	if len(result.FileErrors) > 0 {
+		fmt.Fprintf(f.out, "❌ %d files could not be processed\n", len(result.FileErrors))
+	}
 	if result.Mode == "fix" {
 		if len(result.FixedFiles) > 0 {
-			fmt.Printf("Fixed %d files\n", len(result.FixedFiles))
+			fmt.Fprintf(f.out, "Fixed %d files\n", len(result.FixedFiles))
 		} else {
-			fmt.Printf("No fixes needed\n")
+			fmt.Fprintf(f.out, "No fixes needed\n")
 		}
 	} else {
-		if result.Success {
-			fmt.Printf("✓ All files valid\n")
+		if len(result.Errors) == 0 {
+			fmt.Fprintf(f.out, "✓ %s valid\n", checkedFiles(result))
 		} else {
-			fmt.Printf("❌ %d errors found\n", len(result.Errors))
+			fmt.Fprintf(f.out, "❌ %d errors found\n", len(result.Errors))
 		}
 	}
 }
@@ -0,0 +1,175 @@
+package output
+
+import (
+  "bytes"
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestFormatters(t *testing.T) {
+  errs := []rules.ValidationError{
+    {FilePath: "a.go", Rule: "end_of_line", Message: "uses CRLF", Line: 1, Column: 2, EndLine: 2, EndColumn: 1, Offset: 1, Length: 2},
+    {FilePath: "a.go", Rule: "end_of_line", Message: "uses CRLF", Line: 2, Column: 2, EndLine: 3, EndColumn: 1, Offset: 4, Length: 2},
+  }
+
+  var jsonOut, checkstyleOut, ndjsonOut bytes.Buffer
+  var formatters Formatters
+  for _, target := range []struct {
+    format string
+    out    *bytes.Buffer
+  }{
+    {"json", &jsonOut},
+    {"checkstyle", &checkstyleOut},
+    {"ndjson", &ndjsonOut},
+  } {
+    f := NewFormatter(target.format, false)
+    f.SetOutput(target.out)
+    formatters = append(formatters, f)
+  }
+
+  // Violations are streamed as found, then the full result is formatted
+  formatters.StreamErrors(errs)
+  formatters.FormatResults(&Result{Errors: errs, Violations: 2, TotalFiles: 1, Mode: "validate"})
+
+  tests := []struct {
+    name string
+    got  string
+    want string
+    n    int
+  }{
+    {"json", jsonOut.String(), `"rule": "end_of_line"`, 2},
+    {"checkstyle", checkstyleOut.String(), `source="editorlint.end_of_line"`, 2},
+    {"ndjson", ndjsonOut.String(), `"type":"violation"`, 2},
+    {"ndjson summary", ndjsonOut.String(), `"violations":2`, 1},
+  }
+
+  if formatters.Streaming() || !formatters[2:].Streaming() {
+    t.Error("Streaming() should only be true when every formatter streams")
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      if n := strings.Count(tt.got, tt.want); n != tt.n {
+        t.Errorf("found %s %d times, want %d:\n%s", tt.want, n, tt.n, tt.got)
+      }
+    })
+  }
+}
+
+func TestFormatProgress(t *testing.T) {
+  tests := []struct {
+    name      string
+    dir       bool
+    fix       bool
+    recursive bool
+    want      string
+  }{
+    {"directory", true, false, true, "Validating directory: src (recursive: true)\n"},
+    {"fix directory", true, true, false, "Fixing directory: src (recursive: false)\n"},
+    {"file", false, false, false, "Validating file: src\n"},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      var buf bytes.Buffer
+      FormatProgress(&buf, "src", tt.dir, tt.fix, tt.recursive)
+      if buf.String() != tt.want {
+        t.Errorf("got %q, want %q", buf.String(), tt.want)
+      }
+    })
+  }
+}
+
+func TestInterrupted(t *testing.T) {
+  t.Setenv("GITHUB_STEP_SUMMARY", "")
+
+  result := &Result{
+    Errors:      []rules.ValidationError{{FilePath: "a.go", Rule: "charset", Message: "has BOM"}},
+    Violations:  1,
+    TotalFiles:  4,
+    Mode:        "validate",
+    Interrupted: true,
+  }
+
+  tests := []struct {
+    format string
+    want   string
+  }{
+    {"default", "⚠️  Interrupted before all files were processed; results cover 4 files\n"},
+    {"tabular", "⚠️  Interrupted before all files were processed"},
+    {"quiet", "⚠️  Interrupted before all files were processed"},
+    {"json", `"interrupted": true`},
+    {"ndjson", `"interrupted":true`},
+    {"markdown", "⚠️ **Interrupted before all files were processed; results cover 4 files**"},
+    {"github", "::warning title=editorlint::Interrupted before all files were processed"},
+    {"html", `<p class="fail">⚠️ Interrupted before all files were processed`},
+    {"sarif", `"executionSuccessful": false`},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.format, func(t *testing.T) {
+      var buf bytes.Buffer
+      f := NewFormatter(tt.format, false)
+      f.SetOutput(&buf)
+      f.FormatResults(result)
+
+      if !strings.Contains(buf.String(), tt.want) {
+        t.Errorf("output does not contain %q:\n%s", tt.want, buf.String())
+      }
+    })
+  }
+
+  // Completed runs carry no notice
+  var buf bytes.Buffer
+  f := NewFormatter("default", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{TotalFiles: 4, Success: true, Mode: "validate"})
+  if strings.Contains(buf.String(), "Interrupted") {
+    t.Errorf("completed run reported as interrupted:\n%s", buf.String())
+  }
+}
+
+func TestFileErrors(t *testing.T) {
+  t.Setenv("GITHUB_STEP_SUMMARY", "")
+
+  result := &Result{
+    FixedFiles: []string{"a.go"},
+    Files:      []string{"a.go", "b.go"},
+    TotalFiles: 2,
+    Mode:       "fix",
+    FileErrors: []FileError{{FilePath: "b.go", Message: "could not read file b.go: permission denied"}},
+  }
+
+  tests := []struct {
+    format string
+    want   string
+  }{
+    {"default", "❌ 1 files could not be processed:\n  • b.go - could not read file b.go: permission denied\n"},
+    {"tabular", "❌ 1 files could not be processed:"},
+    {"quiet", "1 files could not be processed"},
+    {"json", `"file_path": "b.go"`},
+    {"ndjson", `{"type":"file_error","file_path":"b.go","message":"could not read file b.go: permission denied"}`},
+    {"markdown", "- `b.go`: could not read file b.go: permission denied"},
+    {"github", "::error file=b.go,title=editorlint::could not read file b.go: permission denied"},
+    {"html", "<li><code>b.go</code>: could not read file b.go: permission denied</li>"},
+    {"sarif", `"uri": "b.go"`},
+    {"junit", `<failure message="could not read file b.go: permission denied" type="file_access">`},
+    {"checkstyle", `source="editorlint.file_access"`},
+    {"gitlab", `"check_name": "file_access"`},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.format, func(t *testing.T) {
+      var buf bytes.Buffer
+      f := NewFormatter(tt.format, false)
+      f.SetOutput(&buf)
+      f.FormatResults(result)
+
+      if !strings.Contains(buf.String(), tt.want) {
+        t.Errorf("output does not contain %q:\n%s", tt.want, buf.String())
+      }
+    })
+  }
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+  "fmt"
+  "io"
+  "os"
+  "sort"
+  "strings"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+// githubSummaryLimit caps the violations listed in the job summary, which
+// GitHub limits to 1MiB per step
+const githubSummaryLimit = 500
+
+// formatGitHub outputs GitHub Actions workflow commands so violations appear
+// as annotations, and writes a job summary when $GITHUB_STEP_SUMMARY is set
+func (f *Formatter) formatGitHub(result *Result) {
+  if notice := interruptedNotice(result); notice != "" {
+    fmt.Fprintf(f.out, "::warning title=editorlint::%s\n", escapeGitHubData(notice))
+  }
+
+  if result.Mode == "fix" {
+    for _, file := range result.FixedFiles {
+      fmt.Fprintf(f.out, "::notice file=%s,title=editorlint::%s\n",
+        escapeGitHubProperty(file), escapeGitHubData("Fixed editorconfig violations"))
+    }
+    fmt.Fprintf(f.out, "%d files processed, %d files fixed\n", result.TotalFiles, len(result.FixedFiles))
+  } else {
+    for _, err := range result.Errors {
+      fmt.Fprintf(f.out, "::error %s::%s\n", githubProperties(err), escapeGitHubData(err.Message))
+    }
+    fmt.Fprintf(f.out, "%d files processed, %d violations found\n", result.TotalFiles, len(result.Errors))
+  }
+
+  for _, fileErr := range result.FileErrors {
+    fmt.Fprintf(f.out, "::error file=%s,title=editorlint::%s\n",
+      escapeGitHubProperty(fileErr.FilePath), escapeGitHubData(fileErr.Message))
+  }
+  if len(result.FileErrors) > 0 {
+    fmt.Fprintf(f.out, "%d files could not be processed\n", len(result.FileErrors))
+  }
+
+  if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+    if err := appendGitHubSummary(path, result); err != nil {
+      fmt.Fprintf(os.Stderr, "Warning: could not write job summary: %v\n", err)
+    }
+  }
+}
+
+// githubProperties returns the annotation properties locating a violation
+func githubProperties(err rules.ValidationError) string {
+  props := []string{"file=" + escapeGitHubProperty(err.FilePath)}
+
+  if err.Line > 0 {
+    props = append(props,
+      fmt.Sprintf("line=%d", err.Line),
+      fmt.Sprintf("col=%d", err.Column))
+
+    // Annotations spanning lines cannot carry columns, so only the end line is given
+    if end := lastLine(err); end > err.Line {
+      props = append(props, fmt.Sprintf("endLine=%d", end))
+    } else if err.EndColumn > err.Column {
+      props = append(props, fmt.Sprintf("endColumn=%d", err.EndColumn))
+    }
+  }
+
+  props = append(props, "title="+escapeGitHubProperty(err.Rule))
+  return strings.Join(props, ",")
+}
+
+// escapeGitHubData escapes a workflow command message
+func escapeGitHubData(s string) string {
+  s = strings.ReplaceAll(s, "%", "%25")
+  s = strings.ReplaceAll(s, "\r", "%0D")
+  return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeGitHubProperty escapes a workflow command property value
+func escapeGitHubProperty(s string) string {
+  s = escapeGitHubData(s)
+  s = strings.ReplaceAll(s, ":", "%3A")
+  return strings.ReplaceAll(s, ",", "%2C")
+}
+
+// appendGitHubSummary appends a Markdown summary of the results to the job summary file
+func appendGitHubSummary(path string, result *Result) error {
+  file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+  if err != nil {
+    return err
+  }
+
+  writeGitHubSummary(file, result)
+  return file.Close()
+}
+
+// writeGitHubSummary writes a Markdown summary of the results
+func writeGitHubSummary(w io.Writer, result *Result) {
+  fmt.Fprintf(w, "## editorlint\n\n")
+
+  if notice := interruptedNotice(result); notice != "" {
+    fmt.Fprintf(w, "⚠️ **%s**\n\n", notice)
+  }
+  writeMarkdownFileErrors(w, result)
+
+  if result.Mode == "fix" {
+    if len(result.FixedFiles) == 0 {
+      fmt.Fprintf(w, "✅ No fixes needed in %d files\n\n", result.TotalFiles)
+      return
+    }
+    fmt.Fprintf(w, "🔧 Fixed %d of %d files:\n\n", len(result.FixedFiles), result.TotalFiles)
+    for _, file := range result.FixedFiles {
+      fmt.Fprintf(w, "- `%s`\n", file)
+    }
+    fmt.Fprintln(w)
+    return
+  }
+
+  if len(result.Errors) == 0 {
+    fmt.Fprintf(w, "✅ All %d files pass editorconfig validation\n\n", result.TotalFiles)
+    return
+  }
+
+  fmt.Fprintf(w, "❌ Found %d violations in %d of %d files\n\n", len(result.Errors), countFiles(result.Errors), result.TotalFiles)
+
+  // Per-rule totals
+  errorsByRule := make(map[string][]rules.ValidationError)
+  var ruleList []string
+  for _, err := range result.Errors {
+    if _, exists := errorsByRule[err.Rule]; !exists {
+      ruleList = append(ruleList, err.Rule)
+    }
+    errorsByRule[err.Rule] = append(errorsByRule[err.Rule], err)
+  }
+  sort.Strings(ruleList)
+
+  fmt.Fprintf(w, "| Rule | Violations | Files |\n|------|-----------:|------:|\n")
+  for _, rule := range ruleList {
+    fmt.Fprintf(w, "| `%s` | %d | %d |\n", rule, len(errorsByRule[rule]), countFiles(errorsByRule[rule]))
+  }
+
+  fmt.Fprintf(w, "\n<details><summary>Violations</summary>\n\n")
+  fmt.Fprintf(w, "| File | Line | Rule | Message |\n|------|-----:|------|---------|\n")
+  for i, err := range result.Errors {
+    if i == githubSummaryLimit {
+      fmt.Fprintf(w, "\n…and %d more\n", len(result.Errors)-githubSummaryLimit)
+      break
+    }
+
+    line := ""
+    if err.Line > 0 {
+      line = fmt.Sprintf("%d", err.Line)
+    }
+    fmt.Fprintf(w, "| `%s` | %s | `%s` | %s |\n", err.FilePath, line, err.Rule, escapeMarkdownCell(err.Message))
+  }
+  fmt.Fprintf(w, "\n</details>\n\n")
+}
+
+// escapeMarkdownCell makes text safe to place in a Markdown table cell
+func escapeMarkdownCell(s string) string {
+  s = strings.ReplaceAll(s, "|", "\\|")
+  return strings.ReplaceAll(s, "\n", " ")
+}
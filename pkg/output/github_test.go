@@ -0,0 +1,97 @@
+package output
+
+import (
+  "bytes"
+  "os"
+  "path/filepath"
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestGitHubProperties(t *testing.T) {
+  tests := []struct {
+    name string
+    err  rules.ValidationError
+    want string
+  }{
+    {
+      name: "single line span",
+      err:  rules.ValidationError{FilePath: "a.go", Rule: "trim_trailing_whitespace", Line: 3, Column: 5, EndLine: 3, EndColumn: 7},
+      want: "file=a.go,line=3,col=5,endColumn=7,title=trim_trailing_whitespace",
+    },
+    {
+      name: "multi-line span",
+      err:  rules.ValidationError{FilePath: "a.go", Rule: "end_of_line", Line: 1, Column: 4, EndLine: 2, EndColumn: 2},
+      want: "file=a.go,line=1,col=4,endLine=2,title=end_of_line",
+    },
+    {
+      name: "span ending at a line break",
+      err:  rules.ValidationError{FilePath: "a.go", Rule: "end_of_line", Line: 1, Column: 4, EndLine: 2, EndColumn: 1},
+      want: "file=a.go,line=1,col=4,title=end_of_line",
+    },
+    {
+      name: "zero-length span",
+      err:  rules.ValidationError{FilePath: "a.go", Rule: "insert_final_newline", Line: 2, Column: 4, EndLine: 2, EndColumn: 4},
+      want: "file=a.go,line=2,col=4,title=insert_final_newline",
+    },
+    {
+      name: "no position",
+      err:  rules.ValidationError{FilePath: "a,b:c.go", Rule: "file_access"},
+      want: "file=a%2Cb%3Ac.go,title=file_access",
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      if got := githubProperties(tt.err); got != tt.want {
+        t.Errorf("Expected %q, got %q", tt.want, got)
+      }
+    })
+  }
+}
+
+func TestFormatGitHub(t *testing.T) {
+  summary := filepath.Join(t.TempDir(), "summary.md")
+  t.Setenv("GITHUB_STEP_SUMMARY", summary)
+
+  var buf bytes.Buffer
+  f := NewFormatter("github", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{
+    Errors: []rules.ValidationError{
+      {FilePath: "a.go", Rule: "max_line_length", Message: "100% too\nlong", Line: 1, Column: 81, EndLine: 1, EndColumn: 90},
+    },
+    TotalFiles: 2,
+    Mode:       "validate",
+  })
+
+  want := "::error file=a.go,line=1,col=81,endColumn=90,title=max_line_length::100%25 too%0Along\n"
+  if !strings.HasPrefix(buf.String(), want) {
+    t.Errorf("Expected output to start with %q, got %q", want, buf.String())
+  }
+
+  content, err := os.ReadFile(summary)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for _, expected := range []string{"## editorlint", "| `max_line_length` | 1 | 1 |", "| `a.go` | 1 | `max_line_length` | 100% too long |"} {
+    if !strings.Contains(string(content), expected) {
+      t.Errorf("Expected job summary to contain %q, got:\n%s", expected, content)
+    }
+  }
+}
+
+func TestFormatGitHubWithoutSummary(t *testing.T) {
+  t.Setenv("GITHUB_STEP_SUMMARY", "")
+
+  var buf bytes.Buffer
+  f := NewFormatter("github", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{TotalFiles: 4, Success: true, Mode: "validate"})
+
+  if got := buf.String(); got != "4 files processed, 0 violations found\n" {
+    t.Errorf("Unexpected output %q", got)
+  }
+}
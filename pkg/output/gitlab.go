@@ -0,0 +1,79 @@
+package output
+
+import (
+  "crypto/sha256"
+  "encoding/hex"
+  "encoding/json"
+  "fmt"
+  "path/filepath"
+)
+
+// GitLab Code Quality report issue
+type gitlabIssue struct {
+  Description string         `json:"description"`
+  CheckName   string         `json:"check_name"`
+  Fingerprint string         `json:"fingerprint"`
+  Severity    string         `json:"severity"`
+  Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+  Path  string      `json:"path"`
+  Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+  Begin int `json:"begin"`
+  End   int `json:"end,omitempty"`
+}
+
+// formatGitLab outputs results as a GitLab Code Quality report
+func (f *Formatter) formatGitLab(result *Result) {
+  issues := []gitlabIssue{}
+  seen := make(map[string]int)
+
+  for _, err := range withFileErrors(result) {
+    path := filepath.ToSlash(filepath.Clean(err.FilePath))
+
+    // Fingerprints identify a violation by its content rather than its line
+    // number so they survive unrelated edits; identical lines are told apart
+    // by their order in the file
+    key := path + "\x00" + err.Rule + "\x00" + err.LineText
+    occurrence := seen[key]
+    seen[key]++
+    sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, occurrence)))
+
+    lines := gitlabLines{Begin: 1}
+    if err.Line > 0 {
+      lines.Begin = err.Line
+      if end := lastLine(err); end > err.Line {
+        lines.End = end
+      }
+    }
+
+    issues = append(issues, gitlabIssue{
+      Description: err.Message,
+      CheckName:   err.Rule,
+      Fingerprint: hex.EncodeToString(sum[:]),
+      Severity:    gitlabSeverity(err.Rule),
+      Location:    gitlabLocation{Path: path, Lines: lines},
+    })
+  }
+
+  encoder := json.NewEncoder(f.out)
+  encoder.SetIndent("", "  ")
+  encoder.Encode(issues)
+}
+
+// gitlabSeverity maps a rule to a Code Quality severity
+func gitlabSeverity(rule string) string {
+  switch rule {
+  case fileAccessRule:
+    return "critical"
+  case "charset":
+    // Encoding problems can corrupt the whole file
+    return "major"
+  default:
+    return "minor"
+  }
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+  "bytes"
+  "encoding/json"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func formatGitLabIssues(t *testing.T, errs []rules.ValidationError) []gitlabIssue {
+  t.Helper()
+
+  var buf bytes.Buffer
+  f := NewFormatter("gitlab", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{Errors: errs, TotalFiles: 1, Mode: "validate"})
+
+  var issues []gitlabIssue
+  if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+    t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+  }
+  return issues
+}
+
+func TestFormatGitLab(t *testing.T) {
+  issues := formatGitLabIssues(t, []rules.ValidationError{
+    {FilePath: "./src/a.go", Rule: "trim_trailing_whitespace", Message: "line 3 has trailing whitespace", Line: 3, EndLine: 3, LineText: "x := 1  "},
+    {FilePath: "src/a.go", Rule: "end_of_line", Message: "line 4 uses CRLF", Line: 4, Column: 5, EndLine: 5, EndColumn: 1, LineText: "}"},
+    {FilePath: "src/b.go", Rule: "file_access", Message: "could not read file"},
+  })
+
+  if len(issues) != 3 {
+    t.Fatalf("Expected 3 issues, got %d", len(issues))
+  }
+
+  first := issues[0]
+  if first.CheckName != "trim_trailing_whitespace" || first.Severity != "minor" || first.Location.Path != "src/a.go" || first.Location.Lines.Begin != 3 {
+    t.Errorf("Unexpected issue %+v", first)
+  }
+
+  if issues[1].Location.Lines.End != 0 {
+    t.Errorf("Expected a line ending to cover a single line, got %+v", issues[1].Location.Lines)
+  }
+
+  if issues[2].Severity != "critical" || issues[2].Location.Lines.Begin != 1 {
+    t.Errorf("Unexpected issue %+v", issues[2])
+  }
+}
+
+func TestFormatGitLabEmpty(t *testing.T) {
+  if issues := formatGitLabIssues(t, nil); issues == nil || len(issues) != 0 {
+    t.Errorf("Expected an empty array, got %v", issues)
+  }
+}
+
+func TestGitLabFingerprints(t *testing.T) {
+  violation := func(line int, text string) rules.ValidationError {
+    return rules.ValidationError{FilePath: "a.go", Rule: "trim_trailing_whitespace", Line: line, EndLine: line, LineText: text}
+  }
+
+  before := formatGitLabIssues(t, []rules.ValidationError{violation(3, "a "), violation(7, "} "), violation(9, "} ")})
+  after := formatGitLabIssues(t, []rules.ValidationError{violation(13, "a "), violation(17, "} "), violation(19, "} ")})
+
+  seen := make(map[string]bool)
+  for i := range before {
+    if before[i].Fingerprint != after[i].Fingerprint {
+      t.Errorf("Fingerprint of issue %d changed when lines moved", i)
+    }
+    if seen[before[i].Fingerprint] {
+      t.Errorf("Fingerprint of issue %d is not unique", i)
+    }
+    seen[before[i].Fingerprint] = true
+  }
+
+  changed := formatGitLabIssues(t, []rules.ValidationError{violation(3, "b ")})
+  if changed[0].Fingerprint == before[0].Fingerprint {
+    t.Error("Expected fingerprint to change with line content")
+  }
+}
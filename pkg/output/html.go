@@ -0,0 +1,318 @@
+package output
+
+import (
+  "fmt"
+  "html/template"
+  "path/filepath"
+  "sort"
+  "strings"
+  "time"
+  "unicode/utf8"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+// htmlReport is the data rendered by htmlTemplate
+type htmlReport struct {
+  Generated   string
+  Mode        string
+  Interrupted string
+  TotalFiles  int
+  Violations  int
+  FailedFiles int
+  FixedFiles  []string
+  FileErrors  []FileError
+  Rules       []htmlCount
+  Directories []htmlDirectory
+  Files       []htmlFile
+}
+
+type htmlCount struct {
+  Name       string
+  Violations int
+  Files      int
+}
+
+type htmlDirectory struct {
+  Path       string
+  Violations int
+  Files      []*htmlFile
+}
+
+type htmlFile struct {
+  Path       string
+  Anchor     string
+  Violations int
+  Rules      string
+  Entries    []htmlEntry
+}
+
+type htmlEntry struct {
+  Line    int
+  Column  int
+  Rule    string
+  Message string
+  Code    template.HTML
+}
+
+// formatHTML outputs results as a self-contained HTML report
+func (f *Formatter) formatHTML(result *Result) {
+  report := htmlReport{
+    Generated:   time.Now().Format("2006-01-02 15:04:05 MST"),
+    Mode:        result.Mode,
+    Interrupted: interruptedNotice(result),
+    TotalFiles:  result.TotalFiles,
+    Violations:  len(result.Errors),
+    FixedFiles:  result.FixedFiles,
+    FileErrors:  result.FileErrors,
+  }
+
+  // Group errors by file and rule, as formatTabular does
+  errorsByFile := make(map[string][]rules.ValidationError)
+  errorsByRule := make(map[string][]rules.ValidationError)
+  for _, err := range result.Errors {
+    errorsByFile[err.FilePath] = append(errorsByFile[err.FilePath], err)
+    errorsByRule[err.Rule] = append(errorsByRule[err.Rule], err)
+  }
+
+  var ruleList []string
+  for rule := range errorsByRule {
+    ruleList = append(ruleList, rule)
+  }
+  sort.Strings(ruleList)
+  for _, rule := range ruleList {
+    report.Rules = append(report.Rules, htmlCount{
+      Name:       rule,
+      Violations: len(errorsByRule[rule]),
+      Files:      countFiles(errorsByRule[rule]),
+    })
+  }
+
+  var files []string
+  for file := range errorsByFile {
+    files = append(files, file)
+  }
+  sort.Strings(files)
+  report.FailedFiles = len(files)
+
+  directories := make(map[string]*htmlDirectory)
+  var directoryList []string
+  for i, file := range files {
+    entry := htmlFileFor(file, fmt.Sprintf("file-%d", i+1), errorsByFile[file])
+    report.Files = append(report.Files, entry)
+
+    dir := filepath.Dir(file)
+    if directories[dir] == nil {
+      directories[dir] = &htmlDirectory{Path: dir}
+      directoryList = append(directoryList, dir)
+    }
+    directories[dir].Violations += entry.Violations
+  }
+
+  // Link directories to the file entries once the slice has stopped growing
+  for i := range report.Files {
+    dir := directories[filepath.Dir(report.Files[i].Path)]
+    dir.Files = append(dir.Files, &report.Files[i])
+  }
+  sort.Strings(directoryList)
+  for _, dir := range directoryList {
+    report.Directories = append(report.Directories, *directories[dir])
+  }
+
+  if err := htmlTemplate.Execute(f.out, report); err != nil {
+    fmt.Fprintf(f.out, "<!-- failed to render report: %s -->\n", template.HTMLEscapeString(err.Error()))
+  }
+}
+
+// htmlFileFor builds the report section for one file
+func htmlFileFor(path, anchor string, errs []rules.ValidationError) htmlFile {
+  entry := htmlFile{Path: path, Anchor: anchor, Violations: len(errs)}
+
+  seen := make(map[string]bool)
+  var ruleNames []string
+  for _, err := range errs {
+    if !seen[err.Rule] {
+      seen[err.Rule] = true
+      ruleNames = append(ruleNames, err.Rule)
+    }
+    entry.Entries = append(entry.Entries, htmlEntry{
+      Line:    err.Line,
+      Column:  err.Column,
+      Rule:    err.Rule,
+      Message: err.Message,
+      Code:    visibleLine(err),
+    })
+  }
+  sort.Strings(ruleNames)
+  entry.Rules = strings.Join(ruleNames, ", ")
+
+  sort.SliceStable(entry.Entries, func(i, j int) bool {
+    return entry.Entries[i].Line < entry.Entries[j].Line
+  })
+
+  return entry
+}
+
+// visibleLine renders the line a violation starts on with whitespace made
+// visible and the violating span highlighted
+func visibleLine(err rules.ValidationError) template.HTML {
+  text := err.LineText + err.LineEnding
+  if err.Line == 0 || text == "" {
+    return ""
+  }
+
+  start := err.Column - 1
+  end := len(text)
+  if err.EndLine == err.Line {
+    end = err.EndColumn - 1
+  }
+  start = clamp(start, 0, len(text))
+  end = clamp(end, start, len(text))
+
+  var b strings.Builder
+  b.WriteString(visibleWhitespace(text[:start], false))
+  if start == end {
+    b.WriteString(`<mark class="point"></mark>`)
+  } else {
+    b.WriteString("<mark>")
+    b.WriteString(visibleWhitespace(text[start:end], true))
+    b.WriteString("</mark>")
+  }
+  b.WriteString(visibleWhitespace(text[end:], false))
+  return template.HTML(b.String())
+}
+
+// visibleWhitespace escapes text for HTML, replacing spaces, tabs and carriage
+// returns with visible symbols. A line feed is only shown when highlighted.
+func visibleWhitespace(text string, highlighted bool) string {
+  var b strings.Builder
+  for len(text) > 0 {
+    r, size := utf8.DecodeRuneInString(text)
+    switch {
+    case r == ' ':
+      b.WriteString(`<span class="ws">·</span>`)
+    case r == '\t':
+      b.WriteString(`<span class="ws">→</span>`)
+    case r == '\r':
+      b.WriteString(`<span class="ws">␍</span>`)
+    case r == '\n':
+      if highlighted {
+        b.WriteString(`<span class="ws">␊</span>`)
+      }
+    case r == utf8.RuneError && size == 1:
+      b.WriteString(fmt.Sprintf(`<span class="ws">\x%02x</span>`, text[0]))
+    default:
+      b.WriteString(template.HTMLEscapeString(text[:size]))
+    }
+    text = text[size:]
+  }
+  return b.String()
+}
+
+func clamp(value, low, high int) int {
+  if value < low {
+    return low
+  }
+  if value > high {
+    return high
+  }
+  return value
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>editorlint report</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #1f2328; }
+h1 { margin-bottom: 0; }
+.meta { color: #59636e; margin-top: 0.25em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d1d9e0; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+th.sortable { cursor: pointer; }
+th.sortable::after { content: " ⇅"; color: #8c959f; }
+td.num { text-align: right; }
+code, pre { font-family: ui-monospace, monospace; }
+pre { margin: 0; white-space: pre-wrap; }
+mark { background: #ffd8d3; }
+mark.point { border-left: 2px solid #cf222e; background: none; }
+.ws { color: #8c959f; }
+.pass { color: #1a7f37; }
+.fail { color: #cf222e; }
+details { margin: 0.5em 0; }
+summary { cursor: pointer; }
+section { margin-top: 2em; }
+</style>
+</head>
+<body>
+<h1>editorlint report</h1>
+<p class="meta">Generated {{.Generated}}</p>
+{{with .Interrupted}}<p class="fail">⚠️ {{.}}</p>{{end}}
+{{with .FileErrors}}<p class="fail">{{len .}} files could not be processed:</p>
+<ul>{{range .}}<li><code>{{.FilePath}}</code>: {{.Message}}</li>{{end}}</ul>{{end}}
+{{if eq .Mode "fix"}}
+<p>Fixed {{len .FixedFiles}} of {{.TotalFiles}} files.</p>
+{{if .FixedFiles}}<ul>{{range .FixedFiles}}<li><code>{{.}}</code></li>{{end}}</ul>{{end}}
+{{else if not .Files}}
+<p class="pass">✓ All {{.TotalFiles}} files pass editorconfig validation.</p>
+{{else}}
+<p class="fail">Found {{.Violations}} violations in {{.FailedFiles}} of {{.TotalFiles}} files.</p>
+
+<h2>By rule</h2>
+<table class="sortable">
+<thead><tr><th class="sortable">Rule</th><th class="sortable">Violations</th><th class="sortable">Files</th></tr></thead>
+<tbody>
+{{range .Rules}}<tr><td><code>{{.Name}}</code></td><td class="num">{{.Violations}}</td><td class="num">{{.Files}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>By directory</h2>
+{{range .Directories}}<details>
+<summary><code>{{.Path}}</code> — {{.Violations}} violations in {{len .Files}} files</summary>
+<ul>{{range .Files}}<li><a href="#{{.Anchor}}"><code>{{.Path}}</code></a> ({{.Violations}})</li>{{end}}</ul>
+</details>
+{{end}}
+
+<h2>Files</h2>
+<table class="sortable">
+<thead><tr><th class="sortable">File</th><th class="sortable">Violations</th><th class="sortable">Rules</th></tr></thead>
+<tbody>
+{{range .Files}}<tr><td><a href="#{{.Anchor}}"><code>{{.Path}}</code></a></td><td class="num">{{.Violations}}</td><td>{{.Rules}}</td></tr>
+{{end}}</tbody>
+</table>
+
+{{range .Files}}<section id="{{.Anchor}}">
+<h3><code>{{.Path}}</code></h3>
+<table>
+<thead><tr><th>Line</th><th>Rule</th><th>Message</th><th>Code</th></tr></thead>
+<tbody>
+{{range .Entries}}<tr><td class="num">{{if .Line}}{{.Line}}:{{.Column}}{{end}}</td><td><code>{{.Rule}}</code></td><td>{{.Message}}</td><td><pre>{{.Code}}</pre></td></tr>
+{{end}}</tbody>
+</table>
+</section>
+{{end}}
+<script>
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th.sortable").forEach(function (th, column) {
+    var ascending = true;
+    th.addEventListener("click", function () {
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[column].textContent, y = b.cells[column].textContent;
+        var nx = parseFloat(x), ny = parseFloat(y);
+        var order = !isNaN(nx) && !isNaN(ny) ? nx - ny : x.localeCompare(y);
+        return ascending ? order : -order;
+      });
+      ascending = !ascending;
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+</script>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,96 @@
+package output
+
+import (
+  "bytes"
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestVisibleLine(t *testing.T) {
+  tests := []struct {
+    name string
+    err  rules.ValidationError
+    want string
+  }{
+    {
+      name: "trailing whitespace",
+      err:  rules.ValidationError{Line: 1, Column: 2, EndLine: 1, EndColumn: 4, LineText: "a \t", LineEnding: "\n"},
+      want: `a<mark><span class="ws">·</span><span class="ws">→</span></mark>`,
+    },
+    {
+      name: "line ending",
+      err:  rules.ValidationError{Line: 1, Column: 3, EndLine: 2, EndColumn: 1, LineText: "<a", LineEnding: "\r\n"},
+      want: `&lt;a<mark><span class="ws">␍</span><span class="ws">␊</span></mark>`,
+    },
+    {
+      name: "insertion point",
+      err:  rules.ValidationError{Line: 1, Column: 4, EndLine: 1, EndColumn: 4, LineText: "end"},
+      want: `end<mark class="point"></mark>`,
+    },
+    {
+      name: "invalid UTF-8",
+      err:  rules.ValidationError{Line: 1, Column: 4, EndLine: 1, EndColumn: 5, LineText: "caf\xe9", LineEnding: "\n"},
+      want: `caf<mark><span class="ws">\xe9</span></mark>`,
+    },
+    {
+      name: "no position",
+      err:  rules.ValidationError{LineText: "ignored"},
+      want: ``,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      if got := string(visibleLine(tt.err)); got != tt.want {
+        t.Errorf("Expected %q, got %q", tt.want, got)
+      }
+    })
+  }
+}
+
+func TestFormatHTML(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("html", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{
+    Errors: []rules.ValidationError{
+      {FilePath: "src/a.go", Rule: "trim_trailing_whitespace", Message: "line 2 has trailing whitespace", Line: 2, Column: 2, EndLine: 2, EndColumn: 3, LineText: "x ", LineEnding: "\n"},
+      {FilePath: "src/b.go", Rule: "end_of_line", Message: "line 1 uses <CRLF>", Line: 1, Column: 2, EndLine: 2, EndColumn: 1, LineText: "y", LineEnding: "\r\n"},
+      {FilePath: "c.go", Rule: "end_of_line", Message: "line 1 uses CRLF", Line: 1, Column: 2, EndLine: 2, EndColumn: 1, LineText: "z", LineEnding: "\r\n"},
+    },
+    TotalFiles: 4,
+    Mode:       "validate",
+  })
+
+  html := buf.String()
+  for _, expected := range []string{
+    "Found 3 violations in 3 of 4 files",
+    "<td><code>end_of_line</code></td><td class=\"num\">2</td><td class=\"num\">2</td>",
+    "<summary><code>src</code> — 2 violations in 2 files</summary>",
+    `<section id="file-2">`,
+    "line 1 uses &lt;CRLF&gt;",
+  } {
+    if !strings.Contains(html, expected) {
+      t.Errorf("Expected report to contain %q", expected)
+    }
+  }
+
+  for _, external := range []string{"<link", "src=\"http", "@import"} {
+    if strings.Contains(html, external) {
+      t.Errorf("Expected report without external assets, found %q", external)
+    }
+  }
+}
+
+func TestFormatHTMLPassing(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("html", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{TotalFiles: 2, Success: true, Mode: "validate"})
+
+  if !strings.Contains(buf.String(), "All 2 files pass") {
+    t.Errorf("Expected passing summary, got:\n%s", buf.String())
+  }
+}
@@ -0,0 +1,202 @@
+package output
+
+import (
+  "encoding/xml"
+  "fmt"
+  "sort"
+  "strings"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+type junitTestSuites struct {
+  XMLName  xml.Name         `xml:"testsuites"`
+  Name     string           `xml:"name,attr"`
+  Tests    int              `xml:"tests,attr"`
+  Failures int              `xml:"failures,attr"`
+  Skipped  int              `xml:"skipped,attr,omitempty"`
+  Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+  Name     string          `xml:"name,attr"`
+  Tests    int             `xml:"tests,attr"`
+  Failures int             `xml:"failures,attr"`
+  Skipped  int             `xml:"skipped,attr,omitempty"`
+  Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+  Name      string        `xml:"name,attr"`
+  ClassName string        `xml:"classname,attr"`
+  Failure   *junitFailure `xml:"failure,omitempty"`
+  Skipped   *junitSkipped `xml:"skipped,omitempty"`
+  SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitSkipped struct {
+  Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+  Message string `xml:"message,attr"`
+  Type    string `xml:"type,attr"`
+  Text    string `xml:",chardata"`
+}
+
+// formatJUnit outputs results as JUnit XML. Each rule is a test suite in
+// which every processed file is a test case that fails if the file has
+// violations of that rule. Files that could not be processed fail in a
+// file_access suite and are skipped in the rule suites, or fail in the fix
+// suite in fix mode.
+func (f *Formatter) formatJUnit(result *Result) {
+  errs := withFileErrors(result)
+
+  files := result.Files
+  if len(files) == 0 {
+    // Without the file list only failing files can be named
+    for _, err := range errs {
+      files = append(files, err.FilePath)
+    }
+  }
+  files = uniqueSorted(files)
+
+  // Suites always report every processed file as a test
+  tests := result.TotalFiles
+  if tests < len(files) {
+    tests = len(files)
+  }
+
+  suites := junitTestSuites{Name: "editorlint"}
+
+  if result.Mode == "fix" {
+    fixed := make(map[string]bool)
+    for _, file := range result.FixedFiles {
+      fixed[file] = true
+    }
+    failed := make(map[string][]rules.ValidationError)
+    for _, err := range errs {
+      failed[err.FilePath] = append(failed[err.FilePath], err)
+    }
+
+    suite := junitTestSuite{Name: "fix", Tests: tests}
+    for _, file := range files {
+      tc := junitTestCase{Name: file, ClassName: "fix"}
+      if fixed[file] {
+        tc.SystemOut = "fixed"
+      }
+      if fileErrs := failed[file]; len(fileErrs) > 0 {
+        tc.Failure = junitFailureFor(fileAccessRule, fileErrs)
+        suite.Failures++
+      }
+      suite.Cases = append(suite.Cases, tc)
+    }
+    suites.Suites = append(suites.Suites, suite)
+  } else {
+    // Group violations by rule and file
+    errorsByRule := make(map[string]map[string][]rules.ValidationError)
+    for _, err := range errs {
+      if errorsByRule[err.Rule] == nil {
+        errorsByRule[err.Rule] = make(map[string][]rules.ValidationError)
+      }
+      errorsByRule[err.Rule][err.FilePath] = append(errorsByRule[err.Rule][err.FilePath], err)
+    }
+
+    // Rules were never checked against files that could not be processed
+    unchecked := make(map[string]string)
+    for _, fileErr := range result.FileErrors {
+      unchecked[fileErr.FilePath] = fileErr.Message
+    }
+
+    for _, rule := range junitRuleNames(result, errorsByRule) {
+      suite := junitTestSuite{Name: rule, Tests: tests}
+      for _, file := range files {
+        tc := junitTestCase{Name: file, ClassName: rule}
+        if errs := errorsByRule[rule][file]; len(errs) > 0 {
+          tc.Failure = junitFailureFor(rule, errs)
+          suite.Failures++
+        } else if message, ok := unchecked[file]; ok && rule != fileAccessRule {
+          tc.Skipped = &junitSkipped{Message: "not checked: " + message}
+          suite.Skipped++
+        }
+        suite.Cases = append(suite.Cases, tc)
+      }
+      suites.Suites = append(suites.Suites, suite)
+    }
+  }
+
+  for _, suite := range suites.Suites {
+    suites.Tests += suite.Tests
+    suites.Failures += suite.Failures
+    suites.Skipped += suite.Skipped
+  }
+
+  fmt.Fprint(f.out, xml.Header)
+  encoder := xml.NewEncoder(f.out)
+  encoder.Indent("", "  ")
+  encoder.Encode(suites)
+  fmt.Fprintln(f.out)
+}
+
+// junitRuleNames returns the rules to report as suites: the applied rules in
+// order, followed by any other rules that produced violations
+func junitRuleNames(result *Result, errorsByRule map[string]map[string][]rules.ValidationError) []string {
+  ruleSet := result.Rules
+  if len(ruleSet) == 0 {
+    ruleSet = rules.All()
+  }
+
+  var names []string
+  known := make(map[string]bool)
+  for _, rule := range ruleSet {
+    names = append(names, rule.Name())
+    known[rule.Name()] = true
+  }
+
+  var extra []string
+  for rule := range errorsByRule {
+    if !known[rule] {
+      extra = append(extra, rule)
+    }
+  }
+  sort.Strings(extra)
+
+  return append(names, extra...)
+}
+
+// junitFailureFor describes the violations of one rule in one file
+func junitFailureFor(rule string, errs []rules.ValidationError) *junitFailure {
+  lines := make([]string, len(errs))
+  for i, err := range errs {
+    if err.Line > 0 {
+      lines[i] = fmt.Sprintf("%s:%d:%d: %s", err.FilePath, err.Line, err.Column, err.Message)
+    } else {
+      lines[i] = fmt.Sprintf("%s: %s", err.FilePath, err.Message)
+    }
+  }
+
+  message := errs[0].Message
+  if len(errs) > 1 {
+    message = fmt.Sprintf("%d violations", len(errs))
+  }
+
+  return &junitFailure{
+    Message: message,
+    Type:    rule,
+    Text:    strings.Join(lines, "\n"),
+  }
+}
+
+// uniqueSorted returns the distinct values in sorted order
+func uniqueSorted(values []string) []string {
+  seen := make(map[string]bool, len(values))
+  var result []string
+  for _, value := range values {
+    if !seen[value] {
+      seen[value] = true
+      result = append(result, value)
+    }
+  }
+  sort.Strings(result)
+  return result
+}
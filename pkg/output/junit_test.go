@@ -0,0 +1,114 @@
+package output
+
+import (
+  "bytes"
+  "encoding/xml"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestFormatJUnit(t *testing.T) {
+  ruleSet := []rules.Rule{rules.NewRule("trim_trailing_whitespace", "", "", nil, nil), rules.NewRule("end_of_line", "", "", nil, nil)}
+
+  var buf bytes.Buffer
+  f := NewFormatter("junit", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{
+    Errors: []rules.ValidationError{
+      {FilePath: "b.go", Rule: "trim_trailing_whitespace", Message: "line 1 has trailing whitespace", Line: 1, Column: 4},
+      {FilePath: "b.go", Rule: "trim_trailing_whitespace", Message: "line 2 has trailing whitespace", Line: 2, Column: 2},
+      {FilePath: "c.go", Rule: "file_access", Message: "could not read file"},
+    },
+    Files:      []string{"c.go", "a.go", "b.go"},
+    TotalFiles: 3,
+    Mode:       "validate",
+    Rules:      ruleSet,
+  })
+
+  var suites junitTestSuites
+  if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+    t.Fatalf("Output is not valid XML: %v\n%s", err, buf.String())
+  }
+
+  if suites.Tests != 9 || suites.Failures != 2 {
+    t.Errorf("Expected 9 tests and 2 failures, got %d and %d", suites.Tests, suites.Failures)
+  }
+
+  var names []string
+  for _, suite := range suites.Suites {
+    names = append(names, suite.Name)
+    if suite.Tests != 3 || len(suite.Cases) != 3 {
+      t.Errorf("Expected suite %s to have 3 test cases, got %d (%d cases)", suite.Name, suite.Tests, len(suite.Cases))
+    }
+  }
+  if len(names) != 3 || names[0] != "trim_trailing_whitespace" || names[1] != "end_of_line" || names[2] != "file_access" {
+    t.Errorf("Unexpected suites %v", names)
+  }
+
+  trim := suites.Suites[0]
+  if trim.Cases[0].Name != "a.go" || trim.Cases[0].Failure != nil {
+    t.Errorf("Expected a.go to pass, got %+v", trim.Cases[0])
+  }
+  failure := trim.Cases[1].Failure
+  if failure == nil || failure.Message != "2 violations" || failure.Text != "b.go:1:4: line 1 has trailing whitespace\nb.go:2:2: line 2 has trailing whitespace" {
+    t.Errorf("Unexpected failure for b.go: %+v", failure)
+  }
+}
+
+func TestFormatJUnitWithoutFileList(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("junit", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{
+    Errors:     []rules.ValidationError{{FilePath: "a.go", Rule: "end_of_line", Message: "wrong"}},
+    TotalFiles: 5,
+    Mode:       "validate",
+    Rules:      []rules.Rule{rules.NewRule("end_of_line", "", "", nil, nil)},
+  })
+
+  var suites junitTestSuites
+  if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+    t.Fatal(err)
+  }
+
+  if suites.Tests != 5 || suites.Failures != 1 {
+    t.Errorf("Expected 5 tests and 1 failure, got %d and %d", suites.Tests, suites.Failures)
+  }
+}
+
+func TestFormatJUnitSkipsUnreadableFiles(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("junit", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{
+    Files:      []string{"a.go", "b.go"},
+    TotalFiles: 2,
+    Mode:       "validate",
+    Rules:      []rules.Rule{rules.NewRule("end_of_line", "", "", nil, nil)},
+    FileErrors: []FileError{{FilePath: "b.go", Message: "could not read file b.go: permission denied"}},
+  })
+
+  var suites junitTestSuites
+  if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+    t.Fatalf("Output is not valid XML: %v\n%s", err, buf.String())
+  }
+
+  if suites.Tests != 4 || suites.Failures != 1 || suites.Skipped != 1 {
+    t.Errorf("Expected 4 tests, 1 failure and 1 skipped, got %d, %d and %d", suites.Tests, suites.Failures, suites.Skipped)
+  }
+
+  eol := suites.Suites[0]
+  if eol.Name != "end_of_line" || eol.Skipped != 1 || eol.Cases[0].Skipped != nil {
+    t.Errorf("Unexpected end_of_line suite %+v", eol)
+  }
+  skipped := eol.Cases[1].Skipped
+  if skipped == nil || skipped.Message != "not checked: could not read file b.go: permission denied" {
+    t.Errorf("Expected b.go to be skipped, got %+v", eol.Cases[1])
+  }
+
+  access := suites.Suites[1]
+  if access.Name != "file_access" || access.Failures != 1 || access.Skipped != 0 {
+    t.Errorf("Unexpected file_access suite %+v", access)
+  }
+}
@@ -0,0 +1,120 @@
+package output
+
+import (
+  "fmt"
+  "io"
+  "sort"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+// defaultMaxEntries keeps Markdown output well under GitHub's 65536
+// character limit for comments
+const defaultMaxEntries = 100
+
+// SetMaxEntries sets how many violations or fixed files the Markdown format
+// lists before truncating. Zero or less lists everything.
+func (f *Formatter) SetMaxEntries(n int) {
+  f.maxEntries = n
+}
+
+// writeMarkdownFileErrors lists the files that could not be processed
+func writeMarkdownFileErrors(w io.Writer, result *Result) {
+  if len(result.FileErrors) == 0 {
+    return
+  }
+
+  fmt.Fprintf(w, "❌ **%d files could not be processed**\n\n", len(result.FileErrors))
+  for _, fileErr := range result.FileErrors {
+    fmt.Fprintf(w, "- `%s`: %s\n", fileErr.FilePath, escapeMarkdownCell(fileErr.Message))
+  }
+  fmt.Fprintln(w)
+}
+
+// formatMarkdown outputs a compact Markdown summary suitable for PR comments
+func (f *Formatter) formatMarkdown(result *Result) {
+  if notice := interruptedNotice(result); notice != "" {
+    fmt.Fprintf(f.out, "⚠️ **%s**\n\n", notice)
+  }
+  writeMarkdownFileErrors(f.out, result)
+
+  if result.Mode == "fix" {
+    if len(result.FixedFiles) == 0 {
+      fmt.Fprintf(f.out, "✅ No fixes needed in %d files\n", result.TotalFiles)
+      return
+    }
+
+    fmt.Fprintf(f.out, "🔧 Fixed **%d** of %d files\n\n", len(result.FixedFiles), result.TotalFiles)
+    for i, file := range result.FixedFiles {
+      if f.maxEntries > 0 && i == f.maxEntries {
+        fmt.Fprintf(f.out, "\n_…and %d more files_\n", len(result.FixedFiles)-i)
+        break
+      }
+      fmt.Fprintf(f.out, "- `%s`\n", file)
+    }
+    return
+  }
+
+  if len(result.Errors) == 0 {
+    fmt.Fprintf(f.out, "✅ All %d files pass editorconfig validation\n", result.TotalFiles)
+    return
+  }
+
+  // Group errors by rule and by file
+  errorsByRule := make(map[string][]rules.ValidationError)
+  errorsByFile := make(map[string][]rules.ValidationError)
+  for _, err := range result.Errors {
+    errorsByRule[err.Rule] = append(errorsByRule[err.Rule], err)
+    errorsByFile[err.FilePath] = append(errorsByFile[err.FilePath], err)
+  }
+
+  var ruleList []string
+  for rule := range errorsByRule {
+    ruleList = append(ruleList, rule)
+  }
+  sort.Strings(ruleList)
+
+  var files []string
+  for file := range errorsByFile {
+    files = append(files, file)
+  }
+  sort.Strings(files)
+
+  fmt.Fprintf(f.out, "❌ **%d** violations in %d of %d files\n\n", len(result.Errors), len(files), result.TotalFiles)
+
+  fmt.Fprintf(f.out, "| Rule | Violations | Files |\n|------|-----------:|------:|\n")
+  for _, rule := range ruleList {
+    fmt.Fprintf(f.out, "| `%s` | %d | %d |\n", rule, len(errorsByRule[rule]), countFiles(errorsByRule[rule]))
+  }
+  fmt.Fprintln(f.out)
+
+  listed := 0
+  for i, file := range files {
+    fileErrors := errorsByFile[file]
+
+    if f.maxEntries > 0 && listed >= f.maxEntries {
+      remaining := 0
+      for _, rest := range files[i:] {
+        remaining += len(errorsByFile[rest])
+      }
+      fmt.Fprintf(f.out, "_…and %d more violations in %d files_\n", remaining, len(files)-i)
+      break
+    }
+
+    fmt.Fprintf(f.out, "<details><summary><code>%s</code> (%d)</summary>\n\n", escapeMarkdownCell(file), len(fileErrors))
+    for j, err := range fileErrors {
+      if f.maxEntries > 0 && listed >= f.maxEntries {
+        fmt.Fprintf(f.out, "- _…and %d more_\n", len(fileErrors)-j)
+        break
+      }
+
+      location := ""
+      if err.Line > 0 {
+        location = fmt.Sprintf("`%d:%d` ", err.Line, err.Column)
+      }
+      fmt.Fprintf(f.out, "- %s**%s** %s\n", location, err.Rule, escapeMarkdownCell(err.Message))
+      listed++
+    }
+    fmt.Fprintf(f.out, "\n</details>\n\n")
+  }
+}
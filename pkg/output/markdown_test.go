@@ -0,0 +1,85 @@
+package output
+
+import (
+  "bytes"
+  "fmt"
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestFormatMarkdown(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("markdown", false)
+  f.SetOutput(&buf)
+  f.FormatResults(&Result{
+    Errors: []rules.ValidationError{
+      {FilePath: "b.go", Rule: "end_of_line", Message: "line 1 uses CRLF", Line: 1, Column: 4},
+      {FilePath: "a.go", Rule: "trim_trailing_whitespace", Message: "a | b", Line: 2, Column: 1},
+      {FilePath: "a.go", Rule: "end_of_line", Message: "line 3 uses CRLF", Line: 3, Column: 2},
+    },
+    TotalFiles: 5,
+    Mode:       "validate",
+  })
+
+  want := "❌ **3** violations in 2 of 5 files\n\n" +
+    "| Rule | Violations | Files |\n|------|-----------:|------:|\n" +
+    "| `end_of_line` | 2 | 2 |\n" +
+    "| `trim_trailing_whitespace` | 1 | 1 |\n\n" +
+    "<details><summary><code>a.go</code> (2)</summary>\n\n" +
+    "- `2:1` **trim_trailing_whitespace** a \\| b\n" +
+    "- `3:2` **end_of_line** line 3 uses CRLF\n\n" +
+    "</details>\n\n" +
+    "<details><summary><code>b.go</code> (1)</summary>\n\n" +
+    "- `1:4` **end_of_line** line 1 uses CRLF\n\n" +
+    "</details>\n\n"
+
+  if got := buf.String(); got != want {
+    t.Errorf("Unexpected output:\n%s\nwant:\n%s", got, want)
+  }
+}
+
+func TestFormatMarkdownTruncates(t *testing.T) {
+  var errs []rules.ValidationError
+  for file := 0; file < 4; file++ {
+    for line := 1; line <= 3; line++ {
+      errs = append(errs, rules.ValidationError{
+        FilePath: fmt.Sprintf("f%d.go", file),
+        Rule:     "trim_trailing_whitespace",
+        Message:  fmt.Sprintf("line %d has trailing whitespace", line),
+        Line:     line,
+        Column:   1,
+      })
+    }
+  }
+
+  tests := []struct {
+    name       string
+    maxEntries int
+    listed     int
+    footer     string
+  }{
+    {"truncates within a file", 4, 4, "_…and 6 more violations in 2 files_"},
+    {"truncates at a file boundary", 6, 6, "_…and 6 more violations in 2 files_"},
+    {"unlimited", -1, 12, ""},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      var buf bytes.Buffer
+      f := NewFormatter("markdown", false)
+      f.SetOutput(&buf)
+      f.SetMaxEntries(tt.maxEntries)
+      f.FormatResults(&Result{Errors: errs, TotalFiles: 4, Mode: "validate"})
+
+      output := buf.String()
+      if listed := strings.Count(output, "**trim_trailing_whitespace** line"); listed != tt.listed {
+        t.Errorf("Expected %d listed violations, got %d", tt.listed, listed)
+      }
+      if tt.footer != "" && !strings.Contains(output, tt.footer) {
+        t.Errorf("Expected output to contain %q, got:\n%s", tt.footer, output)
+      }
+    })
+  }
+}
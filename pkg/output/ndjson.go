@@ -0,0 +1,123 @@
+package output
+
+import (
+  "encoding/json"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+// ndjsonViolation is the record written for each violation
+type ndjsonViolation struct {
+  Type string `json:"type"`
+  jsonError
+}
+
+// ndjsonFixed is the record written for each fixed file
+type ndjsonFixed struct {
+  Type     string `json:"type"`
+  FilePath string `json:"file_path"`
+}
+
+// ndjsonFileError is the record written for each file that could not be processed
+type ndjsonFileError struct {
+  Type string `json:"type"`
+  jsonFileError
+}
+
+// ndjsonSummary is the final record, written once all files are processed
+type ndjsonSummary struct {
+  Type        string `json:"type"`
+  Mode        string `json:"mode"`
+  Success     bool   `json:"success"`
+  Interrupted bool   `json:"interrupted,omitempty"`
+  TotalFiles  int    `json:"total_files"`
+  Violations  int    `json:"violations"`
+  FixedFiles  int    `json:"fixed_files"`
+  FileErrors  int    `json:"file_errors"`
+}
+
+// Streaming reports whether the format writes violations as they are found
+// through StreamErrors and StreamFixed, rather than all at once from the
+// Result passed to FormatResults
+func (f *Formatter) Streaming() bool {
+  return f.format == FormatNDJSON
+}
+
+// StreamErrors writes violations as soon as they are found. It does nothing
+// for formats that are not streaming.
+func (f *Formatter) StreamErrors(errs []rules.ValidationError) {
+  if !f.Streaming() {
+    return
+  }
+
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  f.streamed = true
+  encoder := json.NewEncoder(f.out)
+  for _, err := range errs {
+    encoder.Encode(ndjsonViolation{Type: "violation", jsonError: newJSONError(err)})
+  }
+}
+
+// StreamFixed records a fixed file as soon as it is written. It does nothing
+// for formats that are not streaming.
+func (f *Formatter) StreamFixed(path string) {
+  if !f.Streaming() {
+    return
+  }
+
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  f.streamed = true
+  json.NewEncoder(f.out).Encode(ndjsonFixed{Type: "fixed", FilePath: path})
+}
+
+// StreamFileError records a file that could not be processed as soon as the
+// failure occurs. It does nothing for formats that are not streaming.
+func (f *Formatter) StreamFileError(fileErr FileError) {
+  if !f.Streaming() {
+    return
+  }
+
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  f.streamed = true
+  json.NewEncoder(f.out).Encode(ndjsonFileError{Type: "file_error", jsonFileError: jsonFileError(fileErr)})
+}
+
+// formatNDJSON writes the summary record. If nothing was streamed with
+// StreamErrors, StreamFixed or StreamFileError, the violations, fixed files
+// and file errors in the result are written first.
+func (f *Formatter) formatNDJSON(result *Result) {
+  if !f.streamed {
+    f.StreamErrors(result.Errors)
+    for _, path := range result.FixedFiles {
+      f.StreamFixed(path)
+    }
+    for _, fileErr := range result.FileErrors {
+      f.StreamFileError(fileErr)
+    }
+  }
+
+  violations := result.Violations
+  if violations < len(result.Errors) {
+    violations = len(result.Errors)
+  }
+
+  f.mu.Lock()
+  defer f.mu.Unlock()
+
+  json.NewEncoder(f.out).Encode(ndjsonSummary{
+    Type:        "summary",
+    Mode:        result.Mode,
+    Success:     result.Success,
+    Interrupted: result.Interrupted,
+    TotalFiles:  result.TotalFiles,
+    Violations:  violations,
+    FixedFiles:  len(result.FixedFiles),
+    FileErrors:  len(result.FileErrors),
+  })
+}
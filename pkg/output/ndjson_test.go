@@ -0,0 +1,135 @@
+package output
+
+import (
+  "bytes"
+  "encoding/json"
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestFormatNDJSON(t *testing.T) {
+  streamed := []rules.ValidationError{
+    {FilePath: "a.go", Rule: "end_of_line", Message: "uses CRLF", Line: 2, Column: 5, EndLine: 2, EndColumn: 7, Offset: 12, Length: 2},
+    {FilePath: "b.go", Rule: "charset", Message: "has BOM"},
+  }
+
+  tests := []struct {
+    name        string
+    stream      func(f *Formatter)
+    result      *Result
+    wantTypes   []string
+    wantSummary ndjsonSummary
+  }{
+    {
+      name:        "streamed violations",
+      stream:      func(f *Formatter) { f.StreamErrors(streamed) },
+      result:      &Result{Violations: 2, TotalFiles: 3, Mode: "validate"},
+      wantTypes:   []string{"violation", "violation", "summary"},
+      wantSummary: ndjsonSummary{Type: "summary", Mode: "validate", TotalFiles: 3, Violations: 2},
+    },
+    {
+      name:        "violations only in result",
+      stream:      func(f *Formatter) {},
+      result:      &Result{Errors: streamed, TotalFiles: 3, Mode: "validate"},
+      wantTypes:   []string{"violation", "violation", "summary"},
+      wantSummary: ndjsonSummary{Type: "summary", Mode: "validate", TotalFiles: 3, Violations: 2},
+    },
+    {
+      name:        "fixed files",
+      stream:      func(f *Formatter) { f.StreamFixed("a.go") },
+      result:      &Result{FixedFiles: []string{"a.go"}, TotalFiles: 2, Mode: "fix"},
+      wantTypes:   []string{"fixed", "summary"},
+      wantSummary: ndjsonSummary{Type: "summary", Mode: "fix", TotalFiles: 2, FixedFiles: 1},
+    },
+    {
+      name:        "clean run",
+      stream:      func(f *Formatter) {},
+      result:      &Result{TotalFiles: 4, Success: true, Mode: "validate"},
+      wantTypes:   []string{"summary"},
+      wantSummary: ndjsonSummary{Type: "summary", Mode: "validate", Success: true, TotalFiles: 4},
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      var buf bytes.Buffer
+      f := NewFormatter("ndjson", false)
+      f.SetOutput(&buf)
+      tt.stream(f)
+      f.FormatResults(tt.result)
+
+      lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+      if len(lines) != len(tt.wantTypes) {
+        t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(tt.wantTypes), buf.String())
+      }
+
+      for i, line := range lines {
+        var record struct {
+          Type string `json:"type"`
+        }
+        if err := json.Unmarshal([]byte(line), &record); err != nil {
+          t.Fatalf("line %d is not valid JSON: %v\n%s", i+1, err, line)
+        }
+        if record.Type != tt.wantTypes[i] {
+          t.Errorf("line %d type = %q, want %q", i+1, record.Type, tt.wantTypes[i])
+        }
+      }
+
+      var summary ndjsonSummary
+      json.Unmarshal([]byte(lines[len(lines)-1]), &summary)
+      if summary != tt.wantSummary {
+        t.Errorf("summary = %+v, want %+v", summary, tt.wantSummary)
+      }
+    })
+  }
+}
+
+func TestStreamErrorsRecord(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("ndjson", false)
+  f.SetOutput(&buf)
+  f.StreamErrors([]rules.ValidationError{
+    {FilePath: "a.go", Rule: "end_of_line", Message: "uses CRLF", Line: 1, Column: 4, EndLine: 1, EndColumn: 6, Offset: 3, Length: 2},
+  })
+
+  want := `{"type":"violation","file_path":"a.go","rule":"end_of_line","message":"uses CRLF","line":1,"column":4,"end_line":1,"end_column":6,"offset":3,"length":2}` + "\n"
+  if buf.String() != want {
+    t.Errorf("got %s want %s", buf.String(), want)
+  }
+}
+
+func TestStreamFileErrorRecord(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("ndjson", false)
+  f.SetOutput(&buf)
+  f.StreamFileError(FileError{FilePath: "a.go", Message: "permission denied"})
+  f.FormatResults(&Result{TotalFiles: 1, Mode: "fix", FileErrors: []FileError{{FilePath: "a.go", Message: "permission denied"}}})
+
+  lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+  if len(lines) != 2 {
+    t.Fatalf("got %d records, want the file error and a summary:\n%s", len(lines), buf.String())
+  }
+  if want := `{"type":"file_error","file_path":"a.go","message":"permission denied"}`; lines[0] != want {
+    t.Errorf("got %s want %s", lines[0], want)
+  }
+  if !strings.Contains(lines[1], `"file_errors":1`) {
+    t.Errorf("summary does not count the file error: %s", lines[1])
+  }
+}
+
+func TestStreamingOnlyForNDJSON(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("json", false)
+  f.SetOutput(&buf)
+  f.StreamErrors([]rules.ValidationError{{FilePath: "a.go", Rule: "charset", Message: "has BOM"}})
+  f.StreamFixed("a.go")
+
+  if f.Streaming() {
+    t.Error("json format should not be streaming")
+  }
+  if buf.Len() != 0 {
+    t.Errorf("non-streaming format wrote %q", buf.String())
+  }
+}
@@ -0,0 +1,269 @@
+package output
+
+import (
+  "encoding/json"
+  "fmt"
+  "net/url"
+  "path/filepath"
+  "strings"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+const (
+  sarifVersion = "2.1.0"
+  sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+  toolName     = "editorlint"
+  toolURI      = "https://github.com/dobbo-ca/editorlint"
+)
+
+// SARIF 2.1.0 log structure, limited to the properties editorlint produces
+type sarifLog struct {
+  Schema  string     `json:"$schema"`
+  Version string     `json:"version"`
+  Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+  Tool        sarifTool         `json:"tool"`
+  Invocations []sarifInvocation `json:"invocations,omitempty"`
+  Results     []sarifResult     `json:"results"`
+}
+
+// sarifInvocation is only reported for interrupted runs or files that could
+// not be processed, to mark the run unsuccessful
+type sarifInvocation struct {
+  ExecutionSuccessful        bool                `json:"executionSuccessful"`
+  ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+  Level     string          `json:"level"`
+  Message   sarifMessage    `json:"message"`
+  Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifTool struct {
+  Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+  Name           string                `json:"name"`
+  InformationURI string                `json:"informationUri"`
+  Rules          []sarifRuleDescriptor `json:"rules"`
+}
+
+type sarifRuleDescriptor struct {
+  ID                   string             `json:"id"`
+  ShortDescription     sarifMessage       `json:"shortDescription"`
+  DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+  Properties           sarifRuleProps     `json:"properties"`
+}
+
+type sarifConfiguration struct {
+  Level string `json:"level"`
+}
+
+type sarifRuleProps struct {
+  EditorConfigProperty string `json:"editorconfigProperty"`
+  Fixable              bool   `json:"fixable"`
+}
+
+type sarifMessage struct {
+  Text string `json:"text"`
+}
+
+type sarifResult struct {
+  RuleID    string          `json:"ruleId"`
+  RuleIndex *int            `json:"ruleIndex,omitempty"`
+  Level     string          `json:"level"`
+  Message   sarifMessage    `json:"message"`
+  Locations []sarifLocation `json:"locations"`
+  Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+  PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+  ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+  Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+  URI string `json:"uri"`
+}
+
+// sarifRegion locates a violation by line and byte range. Columns are left
+// out because SARIF counts them in UTF-16 code units rather than bytes.
+type sarifRegion struct {
+  StartLine  int  `json:"startLine,omitempty"`
+  EndLine    int  `json:"endLine,omitempty"`
+  ByteOffset *int `json:"byteOffset,omitempty"`
+  ByteLength *int `json:"byteLength,omitempty"`
+}
+
+type sarifFix struct {
+  Description     sarifMessage          `json:"description"`
+  ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+  ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+  Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+  DeletedRegion   sarifRegion        `json:"deletedRegion"`
+  InsertedContent *sarifInsertedText `json:"insertedContent,omitempty"`
+}
+
+type sarifInsertedText struct {
+  Text string `json:"text"`
+}
+
+// formatSARIF outputs results as a SARIF 2.1.0 log
+func (f *Formatter) formatSARIF(result *Result) {
+  ruleSet := result.Rules
+  if len(ruleSet) == 0 {
+    ruleSet = rules.All()
+  }
+
+  driver := sarifDriver{
+    Name:           toolName,
+    InformationURI: toolURI,
+    Rules:          []sarifRuleDescriptor{},
+  }
+  ruleIndex := make(map[string]int)
+  for i, rule := range ruleSet {
+    ruleIndex[rule.Name()] = i
+    driver.Rules = append(driver.Rules, sarifRuleDescriptor{
+      ID:                   rule.Name(),
+      ShortDescription:     sarifMessage{Text: rule.Description()},
+      DefaultConfiguration: sarifConfiguration{Level: "error"},
+      Properties: sarifRuleProps{
+        EditorConfigProperty: rule.Property(),
+        Fixable:              rule.Fixable(),
+      },
+    })
+  }
+
+  results := []sarifResult{}
+  for _, err := range result.Errors {
+    artifact := sarifArtifactLocation{URI: sarifURI(err.FilePath)}
+
+    res := sarifResult{
+      RuleID:  err.Rule,
+      Level:   "error",
+      Message: sarifMessage{Text: err.Message},
+      Locations: []sarifLocation{{
+        PhysicalLocation: sarifPhysicalLocation{
+          ArtifactLocation: artifact,
+          Region:           sarifErrorRegion(err),
+        },
+      }},
+    }
+
+    // Violations such as unreadable files do not come from a registered rule
+    if i, ok := ruleIndex[err.Rule]; ok {
+      res.RuleIndex = &i
+    }
+
+    if len(err.Edits) > 0 {
+      change := sarifArtifactChange{ArtifactLocation: artifact}
+      for _, edit := range err.Edits {
+        offset, length := edit.Offset, edit.Length
+        replacement := sarifReplacement{
+          DeletedRegion: sarifRegion{ByteOffset: &offset, ByteLength: &length},
+        }
+        if edit.NewText != "" {
+          replacement.InsertedContent = &sarifInsertedText{Text: edit.NewText}
+        }
+        change.Replacements = append(change.Replacements, replacement)
+      }
+
+      res.Fixes = []sarifFix{{
+        Description:     sarifMessage{Text: fmt.Sprintf("Fix %s violation", err.Rule)},
+        ArtifactChanges: []sarifArtifactChange{change},
+      }}
+    }
+
+    results = append(results, res)
+  }
+
+  run := sarifRun{
+    Tool:    sarifTool{Driver: driver},
+    Results: results,
+  }
+  if invocation := sarifFailedInvocation(result); invocation != nil {
+    run.Invocations = []sarifInvocation{*invocation}
+  }
+
+  log := sarifLog{
+    Schema:  sarifSchema,
+    Version: sarifVersion,
+    Runs:    []sarifRun{run},
+  }
+
+  encoder := json.NewEncoder(f.out)
+  encoder.SetIndent("", "  ")
+  encoder.Encode(log)
+}
+
+// sarifFailedInvocation describes why a run was unsuccessful, or returns nil
+// if it was not
+func sarifFailedInvocation(result *Result) *sarifInvocation {
+  if !result.Interrupted && len(result.FileErrors) == 0 {
+    return nil
+  }
+
+  invocation := &sarifInvocation{ExecutionSuccessful: false}
+  if notice := interruptedNotice(result); notice != "" {
+    invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+      Level:   "error",
+      Message: sarifMessage{Text: notice},
+    })
+  }
+  for _, fileErr := range result.FileErrors {
+    invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+      Level:   "error",
+      Message: sarifMessage{Text: fileErr.Message},
+      Locations: []sarifLocation{{
+        PhysicalLocation: sarifPhysicalLocation{
+          ArtifactLocation: sarifArtifactLocation{URI: sarifURI(fileErr.FilePath)},
+        },
+      }},
+    })
+  }
+  return invocation
+}
+
+// sarifErrorRegion returns the region covered by a violation, or nil if it has no position
+func sarifErrorRegion(err rules.ValidationError) *sarifRegion {
+  if err.Line == 0 {
+    return nil
+  }
+
+  offset, length := err.Offset, err.Length
+  return &sarifRegion{
+    StartLine:  err.Line,
+    EndLine:    lastLine(err),
+    ByteOffset: &offset,
+    ByteLength: &length,
+  }
+}
+
+// sarifURI converts a file path into a URI: a file URI for absolute paths and
+// a relative reference otherwise
+func sarifURI(path string) string {
+  u := url.URL{Path: filepath.ToSlash(path)}
+  if filepath.IsAbs(path) {
+    u.Scheme = "file"
+    if !strings.HasPrefix(u.Path, "/") {
+      // Windows drive paths need a leading slash in a file URI
+      u.Path = "/" + u.Path
+    }
+  }
+  return u.String()
+}
@@ -0,0 +1,128 @@
+package output
+
+import (
+  "bytes"
+  "encoding/json"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+  "github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// sampleResult returns a validation result with violations produced by the
+// real rules, including fixable and unfixable ones and one without a position
+func sampleResult(t *testing.T) *Result {
+  t.Helper()
+
+  trueVal := true
+  limit := 10
+  cfg := &config.ResolvedConfig{
+    TrimTrailingWhitespace: &trueVal,
+    InsertFinalNewline:     &trueVal,
+    MaxLineLength:          &limit,
+  }
+
+  var errs []rules.ValidationError
+  for _, rule := range rules.All() {
+    errs = append(errs, rule.Validate("src/main go.txt", []byte("short  \nthis line is too long"), cfg)...)
+  }
+  errs = append(errs, rules.ValidationError{
+    FilePath: "/abs/unreadable.txt",
+    Rule:     "file_access",
+    Message:  "could not read file: permission denied",
+  })
+
+  return &Result{
+    Errors:     errs,
+    TotalFiles: 2,
+    Success:    false,
+    Mode:       "validate",
+  }
+}
+
+func TestFormatSARIFMatchesSchema(t *testing.T) {
+  compiler := jsonschema.NewCompiler()
+  compiler.AssertFormat()
+  schema, err := compiler.Compile("testdata/sarif-schema-2.1.0.json")
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  tests := []struct {
+    name   string
+    result *Result
+  }{
+    {"violations", sampleResult(t)},
+    {"no violations", &Result{TotalFiles: 3, Success: true, Mode: "validate"}},
+    {"interrupted", &Result{TotalFiles: 1, Success: true, Mode: "validate", Interrupted: true}},
+    {"file errors", &Result{TotalFiles: 1, Mode: "fix", FileErrors: []FileError{{FilePath: "a.go", Message: "permission denied"}}}},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      var buf bytes.Buffer
+      f := NewFormatter("sarif", false)
+      f.SetOutput(&buf)
+      f.FormatResults(tt.result)
+
+      doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(buf.Bytes()))
+      if err != nil {
+        t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+      }
+
+      if err := schema.Validate(doc); err != nil {
+        t.Errorf("Output does not match the SARIF schema: %v", err)
+      }
+    })
+  }
+}
+
+func TestFormatSARIF(t *testing.T) {
+  var buf bytes.Buffer
+  f := NewFormatter("sarif", false)
+  f.SetOutput(&buf)
+  f.FormatResults(sampleResult(t))
+
+  var log sarifLog
+  if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+    t.Fatal(err)
+  }
+
+  run := log.Runs[0]
+  if len(run.Tool.Driver.Rules) != len(rules.All()) {
+    t.Errorf("Expected %d rule descriptors, got %d", len(rules.All()), len(run.Tool.Driver.Rules))
+  }
+
+  byRule := make(map[string]sarifResult)
+  for _, res := range run.Results {
+    byRule[res.RuleID] = res
+    if res.RuleIndex != nil && run.Tool.Driver.Rules[*res.RuleIndex].ID != res.RuleID {
+      t.Errorf("Result for %s points at rule descriptor %s", res.RuleID, run.Tool.Driver.Rules[*res.RuleIndex].ID)
+    }
+  }
+
+  trim := byRule["trim_trailing_whitespace"]
+  location := trim.Locations[0].PhysicalLocation
+  if location.ArtifactLocation.URI != "src/main%20go.txt" {
+    t.Errorf("Expected escaped relative URI, got %q", location.ArtifactLocation.URI)
+  }
+  if location.Region == nil || location.Region.StartLine != 1 || *location.Region.ByteOffset != 5 || *location.Region.ByteLength != 2 {
+    t.Errorf("Unexpected region %+v", location.Region)
+  }
+  if len(trim.Fixes) != 1 || len(trim.Fixes[0].ArtifactChanges[0].Replacements) != 1 {
+    t.Errorf("Expected a fix for trailing whitespace, got %+v", trim.Fixes)
+  }
+
+  if len(byRule["max_line_length"].Fixes) != 0 {
+    t.Error("Expected no fix for max_line_length")
+  }
+
+  access := byRule["file_access"]
+  if access.RuleIndex != nil || access.Locations[0].PhysicalLocation.Region != nil {
+    t.Errorf("Expected file_access result without rule index or region, got %+v", access)
+  }
+  if uri := access.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "file:///abs/unreadable.txt" {
+    t.Errorf("Expected file URI for absolute path, got %q", uri)
+  }
+}
@@ -0,0 +1,70 @@
+package output
+
+import (
+  "encoding/json"
+  "fmt"
+  "os"
+  "path/filepath"
+  "strings"
+  "text/template"
+)
+
+// templateFuncs are the helper functions available to user templates
+var templateFuncs = template.FuncMap{
+  "relpath": relPath,
+  "json":    toJSON,
+  "upper":   strings.ToUpper,
+}
+
+// SetTemplate parses the Go text/template used by the template format. The
+// template is executed with the *Result, so violations are available as
+// .Errors, each with the fields of rules.ValidationError.
+func (f *Formatter) SetTemplate(text string) error {
+  tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+  if err != nil {
+    return err
+  }
+  f.template = tmpl
+  return nil
+}
+
+// formatTemplate outputs results using the user-supplied template
+func (f *Formatter) formatTemplate(result *Result) {
+  if f.template == nil {
+    fmt.Fprintf(os.Stderr, "Error: template output requires --template or --template-file\n")
+    return
+  }
+
+  if err := f.template.Execute(f.out, result); err != nil {
+    fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+  }
+}
+
+// relPath returns path relative to the working directory, or path unchanged
+// if it lies outside it
+func relPath(path string) string {
+  wd, err := os.Getwd()
+  if err != nil {
+    return path
+  }
+
+  abs, err := filepath.Abs(path)
+  if err != nil {
+    return path
+  }
+
+  rel, err := filepath.Rel(wd, abs)
+  if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+    return path
+  }
+  return rel
+}
+
+// toJSON encodes a value as compact JSON
+func toJSON(v interface{}) (string, error) {
+  data, err := json.Marshal(v)
+  if err != nil {
+    return "", err
+  }
+  return string(data), nil
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+  "bytes"
+  "os"
+  "path/filepath"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+func TestFormatTemplate(t *testing.T) {
+  wd, err := os.Getwd()
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  result := &Result{
+    Errors: []rules.ValidationError{
+      {FilePath: filepath.Join(wd, "src", "a.go"), Rule: "end_of_line", Message: `uses "CRLF"`, Line: 3, Column: 7},
+    },
+    TotalFiles: 2,
+    Mode:       "validate",
+  }
+
+  tests := []struct {
+    name     string
+    template string
+    want     string
+  }{
+    {
+      name:     "quickfix lines",
+      template: "{{range .Errors}}{{relpath .FilePath}}:{{.Line}}:{{.Column}}: {{.Rule}}: {{.Message}}\n{{end}}",
+      want:     filepath.Join("src", "a.go") + ":3:7: end_of_line: uses \"CRLF\"\n",
+    },
+    {
+      name:     "result fields",
+      template: "{{.Mode | upper}} {{.TotalFiles}} {{len .Errors}} {{.Success}}",
+      want:     "VALIDATE 2 1 false",
+    },
+    {
+      name:     "json helper",
+      template: "{{range .Errors}}{{json .Message}}{{end}}",
+      want:     `"uses \"CRLF\""`,
+    },
+    {
+      name:     "relpath outside working directory",
+      template: `{{relpath "/elsewhere/b.go"}}`,
+      want:     "/elsewhere/b.go",
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      var buf bytes.Buffer
+      f := NewFormatter("template", false)
+      f.SetOutput(&buf)
+      if err := f.SetTemplate(tt.template); err != nil {
+        t.Fatal(err)
+      }
+      f.FormatResults(result)
+
+      if got := buf.String(); got != tt.want {
+        t.Errorf("Expected %q, got %q", tt.want, got)
+      }
+    })
+  }
+}
+
+func TestSetTemplateInvalid(t *testing.T) {
+  f := NewFormatter("template", false)
+  if err := f.SetTemplate("{{range .Errors}"); err == nil {
+    t.Error("Expected error for invalid template, but got none")
+  }
+}
@@ -1,21 +1,39 @@
 package rules
 
-// GetAllValidators returns all available validation functions
+func init() {
+  // Charset must come first so the line-oriented fixers see decoded text
+  Register(NewRule("charset", "File content is encoded in the configured charset",
+    "charset", ValidateCharset, FixCharset))
+  Register(NewRule("insert_final_newline", "File ends with a newline",
+    "insert_final_newline", ValidateInsertFinalNewline, FixInsertFinalNewline))
+  Register(NewRule("trim_trailing_whitespace", "Lines have no trailing whitespace",
+    "trim_trailing_whitespace", ValidateTrimTrailingWhitespace, FixTrimTrailingWhitespace))
+  Register(NewRule("end_of_line", "Line endings match the configured style",
+    "end_of_line", ValidateEndOfLine, FixEndOfLine))
+  Register(NewRule("indent_style", "Indentation uses the configured character",
+    "indent_style", ValidateIndentStyle, FixIndentStyle))
+  Register(NewRule("indent_size", "Indentation is a multiple of the configured size",
+    "indent_size", ValidateIndentSize, nil))
+  Register(NewRule("max_line_length", "Lines do not exceed the configured display width",
+    "max_line_length", ValidateMaxLineLength, nil))
+}
+
+// GetAllValidators returns the validation functions of all registered rules
 func GetAllValidators() []ValidatorFunc {
-  return []ValidatorFunc{
-    ValidateInsertFinalNewline,
-    ValidateTrimTrailingWhitespace,
-    ValidateEndOfLine,
-    // Other validators will be added as we migrate them
+  var validators []ValidatorFunc
+  for _, rule := range All() {
+    validators = append(validators, rule.Validate)
   }
+  return validators
 }
 
-// GetAllFixers returns all available fix functions in the correct order
+// GetAllFixers returns the fix functions of all fixable registered rules in the correct order
 func GetAllFixers() []FixerFunc {
-  return []FixerFunc{
-    FixInsertFinalNewline,
-    FixTrimTrailingWhitespace,
-    FixEndOfLine,
-    // Other fixers will be added as we migrate them
+  var fixers []FixerFunc
+  for _, rule := range All() {
+    if rule.Fixable() {
+      fixers = append(fixers, rule.Fix)
+    }
   }
+  return fixers
 }
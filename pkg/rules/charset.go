@@ -0,0 +1,537 @@
+package rules
+
+import (
+  "bytes"
+  "encoding/binary"
+  "errors"
+  "fmt"
+  "strings"
+  "unicode/utf16"
+  "unicode/utf8"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+  "golang.org/x/text/encoding"
+  "golang.org/x/text/encoding/charmap"
+  "golang.org/x/text/encoding/unicode"
+)
+
+var (
+  bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+  bomUTF16BE = []byte{0xFE, 0xFF}
+  bomUTF16LE = []byte{0xFF, 0xFE}
+)
+
+// ValidateCharset checks if the file content is encoded with the configured charset
+func ValidateCharset(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError {
+  charset := strings.ToLower(cfg.Charset)
+
+  // Only validate if charset is set
+  if charset == "" {
+    return nil
+  }
+
+  if len(content) == 0 {
+    return nil // Empty files are fine
+  }
+
+  var errors []ValidationError
+  bom := detectBOM(content)
+  index := newLineIndex(content)
+
+  // Edits are only offered where FixCharset amounts to adding or removing
+  // a UTF-8 BOM and transcoding individual Latin-1 bytes; anything else is a
+  // whole-file conversion left to the fixer
+  source, detectErr := detectEncoding(content, charset)
+  if detectErr != nil {
+    source = ""
+  }
+  transcode := source == "latin1" && !hasMultibyteUTF8(content)
+
+  // BOM problems cover the BOM itself, or the start of the file if it is missing
+  addBOMError := func(message string, edits ...TextEdit) {
+    err := index.violation(filePath, "charset", 0, bomLength(bom), message)
+    err.Edits = edits
+    errors = append(errors, err)
+  }
+
+  switch charset {
+  case "utf-8":
+    if bom == "UTF-8" && checkUTF8(content, len(bomUTF8)) == "" {
+      addBOMError("file has UTF-8 BOM but charset is set to utf-8 (no BOM)", index.edit(0, len(bomUTF8), ""))
+    } else if bom == "UTF-8" {
+      // FixCharset refuses to strip the BOM while the rest is not valid UTF-8
+      addBOMError("file has UTF-8 BOM but charset is set to utf-8 (no BOM)")
+      errors = appendInvalidUTF8(errors, index, filePath, content, len(bomUTF8), false)
+    } else if bom != "" {
+      addBOMError(fmt.Sprintf("file has %s BOM but charset is set to utf-8 (no BOM)", bom))
+    } else {
+      errors = appendInvalidUTF8(errors, index, filePath, content, 0, transcode)
+    }
+  case "utf-8-bom":
+    if bom == "" {
+      if source == "utf-8" || transcode {
+        addBOMError("file is missing the UTF-8 BOM required by charset utf-8-bom", index.edit(0, 0, string(bomUTF8)))
+      } else {
+        addBOMError("file is missing the UTF-8 BOM required by charset utf-8-bom")
+      }
+      errors = appendInvalidUTF8(errors, index, filePath, content, 0, transcode)
+    } else if bom != "UTF-8" {
+      addBOMError(fmt.Sprintf("file has %s BOM but charset is set to utf-8-bom", bom))
+    } else {
+      errors = appendInvalidUTF8(errors, index, filePath, content, len(bomUTF8), false)
+    }
+  case "latin1":
+    if bom != "" {
+      addBOMError(fmt.Sprintf("file has %s BOM but charset is set to latin1", bom))
+    }
+  case "utf-16be", "utf-16le":
+    // UTF-16 problems concern the whole file
+    if message := checkUTF16(content, charset, bom); message != "" {
+      errors = append(errors, index.violation(filePath, "charset", 0, len(content), message))
+    }
+  default:
+    return nil // Unknown charset
+  }
+
+  return errors
+}
+
+// appendInvalidUTF8 appends a violation for every run of consecutive bytes
+// at or after start that are not part of a valid UTF-8 sequence. When
+// transcode is set each violation carries an edit replacing the bytes with
+// their Latin-1 characters.
+func appendInvalidUTF8(errors []ValidationError, index *lineIndex, filePath string, content []byte, start int, transcode bool) []ValidationError {
+  for i := start; i < len(content); {
+    if !invalidUTF8At(content, i) {
+      _, size := utf8.DecodeRune(content[i:])
+      i += size
+      continue
+    }
+
+    end := i + 1
+    for end < len(content) && invalidUTF8At(content, end) {
+      end++
+    }
+
+    line, _ := index.position(i)
+    message := fmt.Sprintf("invalid UTF-8 byte 0x%02x at offset %d (line %d)", content[i], i, line)
+    if end-i > 1 {
+      message = fmt.Sprintf("%d invalid UTF-8 bytes starting with 0x%02x at offset %d (line %d)", end-i, content[i], i, line)
+    }
+
+    err := index.violation(filePath, "charset", i, end-i, message)
+    if transcode {
+      var latin1 strings.Builder
+      for _, b := range content[i:end] {
+        latin1.WriteRune(rune(b))
+      }
+      err.Edits = []TextEdit{index.edit(i, end-i, latin1.String())}
+    }
+    errors = append(errors, err)
+    i = end
+  }
+
+  return errors
+}
+
+// invalidUTF8At reports whether the byte at i does not start a valid UTF-8 sequence
+func invalidUTF8At(content []byte, i int) bool {
+  r, size := utf8.DecodeRune(content[i:])
+  return r == utf8.RuneError && size == 1
+}
+
+// hasMultibyteUTF8 reports whether content contains any valid multi-byte
+// UTF-8 sequence, which transcoding from Latin-1 would also rewrite
+func hasMultibyteUTF8(content []byte) bool {
+  for i := 0; i < len(content); {
+    r, size := utf8.DecodeRune(content[i:])
+    if r != utf8.RuneError && size > 1 {
+      return true
+    }
+    i += size
+  }
+  return false
+}
+
+// FixCharset re-encodes the file content into the configured charset.
+//
+// The source encoding is detected from a byte order mark, UTF-8 validity or
+// the UTF-16 byte order heuristic, falling back to Latin-1. Content that cannot
+// be decoded in the detected encoding, or that cannot be represented in the
+// target charset, is reported as an error and left untouched.
+func FixCharset(filePath string, content []byte, cfg *config.ResolvedConfig) ([]byte, bool, error) {
+  charset := strings.ToLower(cfg.Charset)
+
+  // Only fix known charsets
+  switch charset {
+  case "utf-8", "utf-8-bom", "latin1", "utf-16be", "utf-16le":
+  default:
+    return content, false, nil
+  }
+
+  if len(content) == 0 {
+    return content, false, nil
+  }
+
+  source, err := detectEncoding(content, charset)
+  if err != nil {
+    return content, false, err
+  }
+
+  if source == charset {
+    // Already in the target encoding; still report content that is not
+    // well-formed rather than claiming it is fine
+    if _, err := decodeCharset(content, source); err != nil {
+      return content, false, err
+    }
+    return content, false, nil
+  }
+
+  text, err := decodeCharset(content, source)
+  if err != nil {
+    return content, false, err
+  }
+
+  result, err := encodeCharset(text, charset)
+  if err != nil {
+    return content, false, err
+  }
+
+  return result, !bytes.Equal(content, result), nil
+}
+
+// asciiCompatible reports whether the configured charset encodes ASCII as
+// single bytes. The line-oriented rules work on raw bytes, so files declared
+// as UTF-16, whose line breaks and whitespace are two bytes wide, are passed
+// to them decoded by validateDecoded and fixDecoded.
+func asciiCompatible(cfg *config.ResolvedConfig) bool {
+  charset := strings.ToLower(cfg.Charset)
+  return charset != "utf-16be" && charset != "utf-16le"
+}
+
+// validateDecoded runs a line-oriented validator on UTF-16 content decoded
+// to UTF-8. Byte ranges are mapped back to the UTF-16 content; edits are
+// dropped, since their text would need encoding too. Content that is not
+// well-formed UTF-16 is left to the charset rule.
+func validateDecoded(filePath string, content []byte, cfg *config.ResolvedConfig, validate ValidatorFunc) []ValidationError {
+  text, _, ok := decodeUTF16(content, cfg)
+  if !ok {
+    return nil
+  }
+
+  errs := validate(filePath, text, utf8Config(cfg))
+  offsets := utf16Offsets(text, bomLength(detectBOM(content)))
+  for i := range errs {
+    start := clampOffset(errs[i].Offset, len(text))
+    end := clampOffset(errs[i].Offset+errs[i].Length, len(text))
+    errs[i].Offset = offsets[start]
+    errs[i].Length = offsets[end] - offsets[start]
+    errs[i].Edits = nil
+  }
+  return errs
+}
+
+// fixDecoded runs a line-oriented fixer on UTF-16 content decoded to UTF-8,
+// then encodes the result in the content's byte order, keeping or omitting
+// its BOM. Content that is not well-formed UTF-16 is left to the charset rule.
+func fixDecoded(filePath string, content []byte, cfg *config.ResolvedConfig, fix FixerFunc) ([]byte, bool, error) {
+  text, source, ok := decodeUTF16(content, cfg)
+  if !ok {
+    return content, false, nil
+  }
+
+  fixed, changed, err := fix(filePath, text, utf8Config(cfg))
+  if err != nil || !changed {
+    return content, false, err
+  }
+
+  endianness := unicode.BigEndian
+  if source == "utf-16le" {
+    endianness = unicode.LittleEndian
+  }
+  bom := unicode.IgnoreBOM
+  if detectBOM(content) != "" {
+    bom = unicode.UseBOM
+  }
+  result, err := unicode.UTF16(endianness, bom).NewEncoder().Bytes(fixed)
+  if err != nil {
+    return content, false, fmt.Errorf("content cannot be represented in %s: %w", source, err)
+  }
+  return result, !bytes.Equal(content, result), nil
+}
+
+// decodeUTF16 decodes content declared as UTF-16 to UTF-8, returning the
+// byte order it is in, or ok false if it is not well-formed UTF-16
+func decodeUTF16(content []byte, cfg *config.ResolvedConfig) (text []byte, source string, ok bool) {
+  source, err := detectEncoding(content, strings.ToLower(cfg.Charset))
+  if err != nil || (source != "utf-16be" && source != "utf-16le") {
+    return nil, "", false
+  }
+
+  text, err = decodeCharset(content, source)
+  if err != nil {
+    return nil, "", false
+  }
+  return text, source, true
+}
+
+// utf8Config returns a copy of cfg declaring UTF-8, for running rules on
+// decoded text
+func utf8Config(cfg *config.ResolvedConfig) *config.ResolvedConfig {
+  decoded := *cfg
+  decoded.Charset = "utf-8"
+  return &decoded
+}
+
+// utf16Offsets maps every byte offset in UTF-8 text, and its end, to the
+// offset of the same position in the UTF-16 encoding of the text after a
+// BOM of bomLen bytes
+func utf16Offsets(text []byte, bomLen int) []int {
+  offsets := make([]int, len(text)+1)
+  position := bomLen
+  for i := 0; i < len(text); {
+    r, size := utf8.DecodeRune(text[i:])
+    for j := 0; j < size; j++ {
+      offsets[i+j] = position
+    }
+    units := 1
+    if r > 0xFFFF {
+      units = 2
+    }
+    position += 2 * units
+    i += size
+  }
+  offsets[len(text)] = position
+  return offsets
+}
+
+func clampOffset(offset, length int) int {
+  if offset < 0 {
+    return 0
+  }
+  if offset > length {
+    return length
+  }
+  return offset
+}
+
+// detectEncoding determines the charset content is currently encoded in,
+// using the declared charset to resolve ambiguous cases
+func detectEncoding(content []byte, declared string) (string, error) {
+  switch detectBOM(content) {
+  case "UTF-8":
+    return "utf-8-bom", nil
+  case "UTF-16BE":
+    return "utf-16be", nil
+  case "UTF-16LE":
+    return "utf-16le", nil
+  }
+
+  if declared == "utf-16be" || declared == "utf-16le" {
+    if order := guessUTF16ByteOrder(content); order != "" {
+      return strings.ToLower(order), nil
+    }
+  }
+
+  // Every byte sequence is valid Latin-1, so a declared latin1 file without
+  // a BOM is taken at its word
+  if declared == "latin1" {
+    return "latin1", nil
+  }
+
+  if utf8.Valid(content) {
+    return "utf-8", nil
+  }
+
+  // C1 control characters essentially never appear in real Latin-1 text;
+  // their presence points at another 8-bit encoding or a corrupted file
+  for _, b := range content {
+    if b >= 0x80 && b <= 0x9F {
+      return "", fmt.Errorf("cannot determine source encoding: content is neither valid UTF-8 nor Latin-1 (byte 0x%02x)", b)
+    }
+  }
+
+  // Transcoding a file that is mostly UTF-8 with a stray Latin-1 byte from
+  // Latin-1 would garble its valid multi-byte characters
+  if hasMultibyteUTF8(content) {
+    return "", fmt.Errorf("cannot determine source encoding: content mixes valid UTF-8 with invalid bytes (%s)", checkUTF8(content, 0))
+  }
+
+  return "latin1", nil
+}
+
+// decodeCharset converts content in the given charset to UTF-8
+func decodeCharset(content []byte, charset string) ([]byte, error) {
+  switch charset {
+  case "utf-8", "utf-8-bom":
+    // Check past the BOM so offsets match the file, as ValidateCharset reports them
+    start := 0
+    if bytes.HasPrefix(content, bomUTF8) {
+      start = len(bomUTF8)
+    }
+    if msg := checkUTF8(content, start); msg != "" {
+      return nil, errors.New(msg)
+    }
+    return content[start:], nil
+  case "latin1":
+    return charmap.ISO8859_1.NewDecoder().Bytes(content)
+  case "utf-16be", "utf-16le":
+    order := binary.ByteOrder(binary.BigEndian)
+    endianness := unicode.BigEndian
+    if charset == "utf-16le" {
+      order = binary.LittleEndian
+      endianness = unicode.LittleEndian
+    }
+    if err := checkUTF16Units(content, order, charset); err != nil {
+      return nil, err
+    }
+    // The x/text decoder substitutes U+FFFD for malformed input, so the
+    // content is checked above before decoding
+    return unicode.UTF16(endianness, unicode.UseBOM).NewDecoder().Bytes(content)
+  default:
+    return nil, fmt.Errorf("unsupported charset %s", charset)
+  }
+}
+
+// encodeCharset converts UTF-8 text to the given charset
+func encodeCharset(text []byte, charset string) ([]byte, error) {
+  var encoder *encoding.Encoder
+
+  switch charset {
+  case "utf-8":
+    return text, nil
+  case "utf-8-bom":
+    return append(append([]byte{}, bomUTF8...), text...), nil
+  case "latin1":
+    encoder = charmap.ISO8859_1.NewEncoder()
+  case "utf-16be":
+    encoder = unicode.UTF16(unicode.BigEndian, unicode.UseBOM).NewEncoder()
+  case "utf-16le":
+    encoder = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder()
+  default:
+    return nil, fmt.Errorf("unsupported charset %s", charset)
+  }
+
+  result, err := encoder.Bytes(text)
+  if err != nil {
+    return nil, fmt.Errorf("content cannot be represented in %s: %w", charset, err)
+  }
+  return result, nil
+}
+
+// checkUTF16Units verifies content is a whole number of UTF-16 code units
+// with no unpaired surrogates
+func checkUTF16Units(content []byte, order binary.ByteOrder, charset string) error {
+  if len(content)%2 != 0 {
+    return fmt.Errorf("content has an odd number of bytes (%d) and is not valid %s", len(content), charset)
+  }
+
+  for i := 0; i < len(content); i += 2 {
+    unit := order.Uint16(content[i:])
+    if !utf16.IsSurrogate(rune(unit)) {
+      continue
+    }
+    if unit < 0xDC00 && i+3 < len(content) {
+      next := order.Uint16(content[i+2:])
+      if next >= 0xDC00 && next <= 0xDFFF {
+        i += 2
+        continue
+      }
+    }
+    return fmt.Errorf("unpaired UTF-16 surrogate 0x%04x at offset %d", unit, i)
+  }
+
+  return nil
+}
+
+// bomLength returns the length in bytes of the BOM named by detectBOM
+func bomLength(bom string) int {
+  switch bom {
+  case "UTF-8":
+    return len(bomUTF8)
+  case "UTF-16BE", "UTF-16LE":
+    return len(bomUTF16BE)
+  default:
+    return 0
+  }
+}
+
+// detectBOM returns the name of the encoding indicated by a byte order mark
+// at the start of content, or an empty string if there is none
+func detectBOM(content []byte) string {
+  switch {
+  case bytes.HasPrefix(content, bomUTF8):
+    return "UTF-8"
+  case bytes.HasPrefix(content, bomUTF16BE):
+    return "UTF-16BE"
+  case bytes.HasPrefix(content, bomUTF16LE):
+    return "UTF-16LE"
+  default:
+    return ""
+  }
+}
+
+// checkUTF8 returns a description of the first invalid UTF-8 sequence in
+// content at or after start, or an empty string if the content is well-formed
+func checkUTF8(content []byte, start int) string {
+  for i := start; i < len(content); {
+    r, size := utf8.DecodeRune(content[i:])
+    if r == utf8.RuneError && size == 1 {
+      line := bytes.Count(content[:i], []byte("\n")) + 1
+      return fmt.Sprintf("invalid UTF-8 byte 0x%02x at offset %d (line %d)", content[i], i, line)
+    }
+    i += size
+  }
+  return ""
+}
+
+// checkUTF16 returns a description of why content does not look like UTF-16
+// in the declared byte order, or an empty string if it does
+func checkUTF16(content []byte, charset string, bom string) string {
+  expected := strings.ToUpper(charset)
+
+  switch bom {
+  case expected:
+    // Correct BOM; still verify the length below
+  case "":
+    if detected := guessUTF16ByteOrder(content); detected != "" && detected != expected {
+      return fmt.Sprintf("file appears to be %s but charset is set to %s", detected, charset)
+    } else if detected == "" && utf8.Valid(content) {
+      return fmt.Sprintf("file appears to be 8-bit encoded but charset is set to %s", charset)
+    }
+  default:
+    return fmt.Sprintf("file has %s BOM but charset is set to %s", bom, charset)
+  }
+
+  if len(content)%2 != 0 {
+    return fmt.Sprintf("file has an odd number of bytes (%d) and cannot be %s", len(content), charset)
+  }
+
+  return ""
+}
+
+// guessUTF16ByteOrder guesses the byte order of BOM-less UTF-16 content from
+// where zero bytes appear. Text that is mostly ASCII has its high byte zero,
+// which lands on even offsets for big-endian and odd offsets for little-endian.
+func guessUTF16ByteOrder(content []byte) string {
+  evenZeros, oddZeros := 0, 0
+  for i, b := range content {
+    if b != 0 {
+      continue
+    }
+    if i%2 == 0 {
+      evenZeros++
+    } else {
+      oddZeros++
+    }
+  }
+
+  switch {
+  case evenZeros > oddZeros:
+    return "UTF-16BE"
+  case oddZeros > evenZeros:
+    return "UTF-16LE"
+  default:
+    return ""
+  }
+}
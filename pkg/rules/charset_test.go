@@ -0,0 +1,426 @@
+package rules
+
+import (
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+func TestValidateCharset(t *testing.T) {
+  tests := []struct {
+    name      string
+    content   string
+    charset   string
+    wantError bool
+  }{
+    {
+      name:      "valid UTF-8",
+      content:   "héllo\n",
+      charset:   "utf-8",
+      wantError: false,
+    },
+    {
+      name:      "UTF-8 with unexpected BOM",
+      content:   "\xEF\xBB\xBFhello\n",
+      charset:   "utf-8",
+      wantError: true,
+    },
+    {
+      name:      "stray Latin-1 byte in UTF-8",
+      content:   "caf\xe9\n",
+      charset:   "utf-8",
+      wantError: true,
+    },
+    {
+      name:      "UTF-8 BOM present",
+      content:   "\xEF\xBB\xBFhello\n",
+      charset:   "utf-8-bom",
+      wantError: false,
+    },
+    {
+      name:      "UTF-8 BOM missing",
+      content:   "hello\n",
+      charset:   "utf-8-bom",
+      wantError: true,
+    },
+    {
+      name:      "UTF-8 BOM with invalid bytes",
+      content:   "\xEF\xBB\xBFcaf\xe9\n",
+      charset:   "utf-8-bom",
+      wantError: true,
+    },
+    {
+      name:      "Latin-1 bytes",
+      content:   "caf\xe9\n",
+      charset:   "latin1",
+      wantError: false,
+    },
+    {
+      name:      "Latin-1 with UTF-8 BOM",
+      content:   "\xEF\xBB\xBFcafe\n",
+      charset:   "latin1",
+      wantError: true,
+    },
+    {
+      name:      "UTF-16LE with BOM",
+      content:   "\xFF\xFEh\x00i\x00",
+      charset:   "utf-16le",
+      wantError: false,
+    },
+    {
+      name:      "UTF-16BE BOM when expecting UTF-16LE",
+      content:   "\xFE\xFF\x00h\x00i",
+      charset:   "utf-16le",
+      wantError: true,
+    },
+    {
+      name:      "BOM-less UTF-16LE when expecting UTF-16BE",
+      content:   "h\x00i\x00",
+      charset:   "utf-16be",
+      wantError: true,
+    },
+    {
+      name:      "BOM-less UTF-16BE",
+      content:   "\x00h\x00i",
+      charset:   "utf-16be",
+      wantError: false,
+    },
+    {
+      name:      "8-bit text when expecting UTF-16",
+      content:   "hello\n",
+      charset:   "utf-16le",
+      wantError: true,
+    },
+    {
+      name:      "odd length UTF-16",
+      content:   "\xFF\xFEh\x00i",
+      charset:   "utf-16le",
+      wantError: true,
+    },
+    {
+      name:      "charset unset",
+      content:   "caf\xe9\n",
+      charset:   "",
+      wantError: false,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      cfg := &config.ResolvedConfig{
+        Charset: tt.charset,
+      }
+
+      errs := ValidateCharset("test.txt", []byte(tt.content), cfg)
+
+      if tt.wantError && len(errs) == 0 {
+        t.Error("Expected validation error, but got none")
+      }
+
+      if !tt.wantError && len(errs) > 0 {
+        t.Errorf("Expected no validation error, but got: %v", errs)
+      }
+    })
+  }
+}
+
+func TestValidateCharsetReportsEveryInvalidByte(t *testing.T) {
+  cfg := &config.ResolvedConfig{Charset: "utf-8"}
+
+  errs := ValidateCharset("test.txt", []byte("ok\ncaf\xe9\nna\xefve\n"), cfg)
+  if len(errs) != 2 {
+    t.Fatalf("Expected 2 validation errors, got %d: %v", len(errs), errs)
+  }
+
+  if !strings.Contains(errs[0].Message, "offset 6 (line 2)") || errs[0].Line != 2 {
+    t.Errorf("Expected first error at offset 6 on line 2, got %+v", errs[0])
+  }
+
+  if errs[1].Line != 3 {
+    t.Errorf("Expected second error on line 3, got %+v", errs[1])
+  }
+}
+
+func TestFixCharset(t *testing.T) {
+  tests := []struct {
+    name            string
+    content         string
+    charset         string
+    expectedContent string
+    expectFixed     bool
+    expectErr       bool
+  }{
+    {
+      name:            "latin1 to utf-8",
+      content:         "caf\xe9\n",
+      charset:         "utf-8",
+      expectedContent: "café\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "utf-8 to utf-8-bom",
+      content:         "hello\n",
+      charset:         "utf-8-bom",
+      expectedContent: "\xEF\xBB\xBFhello\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "strip unexpected UTF-8 BOM",
+      content:         "\xEF\xBB\xBFhello\n",
+      charset:         "utf-8",
+      expectedContent: "hello\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "utf-16le to utf-8",
+      content:         "\xFF\xFEh\x00\xe9\x00\n\x00",
+      charset:         "utf-8",
+      expectedContent: "hé\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "utf-8 to utf-16le",
+      content:         "hi",
+      charset:         "utf-16le",
+      expectedContent: "\xFF\xFEh\x00i\x00",
+      expectFixed:     true,
+    },
+    {
+      name:            "utf-16be BOM to utf-16le",
+      content:         "\xFE\xFF\x00h\x00i",
+      charset:         "utf-16le",
+      expectedContent: "\xFF\xFEh\x00i\x00",
+      expectFixed:     true,
+    },
+    {
+      name:            "utf-8 BOM to latin1",
+      content:         "\xEF\xBB\xBFcafé",
+      charset:         "latin1",
+      expectedContent: "caf\xe9",
+      expectFixed:     true,
+    },
+    {
+      name:            "already utf-8",
+      content:         "café\n",
+      charset:         "utf-8",
+      expectedContent: "café\n",
+      expectFixed:     false,
+    },
+    {
+      name:            "C1 control bytes are not Latin-1",
+      content:         "smart \x93quotes\x94\n",
+      charset:         "utf-8",
+      expectedContent: "smart \x93quotes\x94\n",
+      expectErr:       true,
+    },
+    {
+      name:            "UTF-8 mixed with a Latin-1 byte",
+      content:         "caf\xc3\xa9 na\xefve\n",
+      charset:         "utf-8",
+      expectedContent: "caf\xc3\xa9 na\xefve\n",
+      expectErr:       true,
+    },
+    {
+      name:            "UTF-8 mixed with a Latin-1 byte to utf-8-bom",
+      content:         "caf\xc3\xa9 na\xefve\n",
+      charset:         "utf-8-bom",
+      expectedContent: "caf\xc3\xa9 na\xefve\n",
+      expectErr:       true,
+    },
+    {
+      name:            "odd length UTF-16",
+      content:         "\xFF\xFEh\x00i",
+      charset:         "utf-8",
+      expectedContent: "\xFF\xFEh\x00i",
+      expectErr:       true,
+    },
+    {
+      name:            "unpaired surrogate",
+      content:         "\xFF\xFE\x00\xD8a\x00",
+      charset:         "utf-8",
+      expectedContent: "\xFF\xFE\x00\xD8a\x00",
+      expectErr:       true,
+    },
+    {
+      name:            "unrepresentable in latin1",
+      content:         "\xEF\xBB\xBF日本",
+      charset:         "latin1",
+      expectedContent: "\xEF\xBB\xBF日本",
+      expectErr:       true,
+    },
+    {
+      name:            "charset unset",
+      content:         "caf\xe9\n",
+      charset:         "",
+      expectedContent: "caf\xe9\n",
+      expectFixed:     false,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      cfg := &config.ResolvedConfig{
+        Charset: tt.charset,
+      }
+
+      newContent, fixed, err := FixCharset("test.txt", []byte(tt.content), cfg)
+
+      if tt.expectErr && err == nil {
+        t.Error("Expected error, but got none")
+      }
+
+      if !tt.expectErr && err != nil {
+        t.Fatalf("Expected no error, but got: %v", err)
+      }
+
+      if fixed != tt.expectFixed {
+        t.Errorf("Expected fixed=%v, got %v", tt.expectFixed, fixed)
+      }
+
+      if string(newContent) != tt.expectedContent {
+        t.Errorf("Expected content %q, got %q", tt.expectedContent, string(newContent))
+      }
+    })
+  }
+}
+
+func TestLineRulesDecodeUTF16(t *testing.T) {
+  trueVal := true
+  utf16le := func(s string) string {
+    var b strings.Builder
+    for _, r := range s {
+      b.WriteByte(byte(r))
+      b.WriteByte(0)
+    }
+    return b.String()
+  }
+
+  tests := []struct {
+    name            string
+    content         string
+    cfg             config.ResolvedConfig
+    validator       ValidatorFunc
+    fixer           FixerFunc
+    errors          int
+    offset          int
+    expectedContent string
+  }{
+    {
+      name:            "trailing whitespace with BOM",
+      content:         "\xFF\xFE" + utf16le("a \n"),
+      cfg:             config.ResolvedConfig{Charset: "utf-16le", TrimTrailingWhitespace: &trueVal},
+      validator:       ValidateTrimTrailingWhitespace,
+      fixer:           FixTrimTrailingWhitespace,
+      errors:          1,
+      offset:          4,
+      expectedContent: "\xFF\xFE" + utf16le("a\n"),
+    },
+    {
+      name:            "line endings without BOM",
+      content:         "\x00a\x00\r\x00\n",
+      cfg:             config.ResolvedConfig{Charset: "utf-16be", EndOfLine: "lf"},
+      validator:       ValidateEndOfLine,
+      fixer:           FixEndOfLine,
+      errors:          1,
+      offset:          2,
+      expectedContent: "\x00a\x00\n",
+    },
+    {
+      name:            "indentation",
+      content:         "\xFF\xFE" + utf16le("\tx\n"),
+      cfg:             config.ResolvedConfig{Charset: "utf-16le", IndentStyle: "space"},
+      validator:       ValidateIndentStyle,
+      fixer:           FixIndentStyle,
+      errors:          1,
+      offset:          2,
+      expectedContent: "\xFF\xFE" + utf16le("    x\n"),
+    },
+    {
+      name:            "malformed UTF-16 is left to the charset rule",
+      content:         "\xFF\xFEa \x00",
+      cfg:             config.ResolvedConfig{Charset: "utf-16le", TrimTrailingWhitespace: &trueVal},
+      validator:       ValidateTrimTrailingWhitespace,
+      fixer:           FixTrimTrailingWhitespace,
+      errors:          0,
+      expectedContent: "\xFF\xFEa \x00",
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      errs := tt.validator("test.txt", []byte(tt.content), &tt.cfg)
+      if len(errs) != tt.errors {
+        t.Fatalf("Expected %d errors, got %d: %v", tt.errors, len(errs), errs)
+      }
+      for _, err := range errs {
+        if err.Offset != tt.offset || len(err.Edits) != 0 {
+          t.Errorf("Expected offset %d in the UTF-16 content and no edits, got %+v", tt.offset, err)
+        }
+      }
+
+      fixed, _, err := tt.fixer("test.txt", []byte(tt.content), &tt.cfg)
+      if err != nil {
+        t.Fatal(err)
+      }
+      if string(fixed) != tt.expectedContent {
+        t.Errorf("Expected content %q, got %q", tt.expectedContent, string(fixed))
+      }
+    })
+  }
+}
+
+func TestValidateCharsetReportsInvalidBytesAfterBOM(t *testing.T) {
+  cfg := &config.ResolvedConfig{Charset: "utf-8"}
+
+  errs := ValidateCharset("test.txt", []byte("\xEF\xBB\xBFcaf\xe9\n"), cfg)
+  if len(errs) != 2 {
+    t.Fatalf("Expected the BOM and the invalid byte to be reported, got %d: %v", len(errs), errs)
+  }
+
+  if errs[0].Offset != 0 || len(errs[0].Edits) != 0 {
+    t.Errorf("Expected a BOM error without edits, got %+v", errs[0])
+  }
+  if errs[1].Offset != 6 || !strings.Contains(errs[1].Message, "offset 6") {
+    t.Errorf("Expected the invalid byte at offset 6, got %+v", errs[1])
+  }
+}
+
+func TestValidateCharsetMergesInvalidRuns(t *testing.T) {
+  cfg := &config.ResolvedConfig{Charset: "utf-8"}
+  content := []byte("cr\xe8\xe9\xeame \xe0\n")
+
+  errs := ValidateCharset("test.txt", content, cfg)
+  if len(errs) != 2 {
+    t.Fatalf("Expected one error per run of invalid bytes, got %d: %v", len(errs), errs)
+  }
+
+  if errs[0].Offset != 2 || errs[0].Length != 3 || !strings.Contains(errs[0].Message, "3 invalid UTF-8 bytes") {
+    t.Errorf("Expected a run of 3 bytes at offset 2, got %+v", errs[0])
+  }
+  if errs[1].Offset != 8 || errs[1].Length != 1 {
+    t.Errorf("Expected a single byte at offset 8, got %+v", errs[1])
+  }
+
+  // Each run's edit transcodes the whole run
+  var edits []TextEdit
+  for _, err := range errs {
+    edits = append(edits, err.Edits...)
+  }
+  edited, err := ApplyEdits(content, edits)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if string(edited) != "crèéême à\n" {
+    t.Errorf("Expected edits to transcode the runs, got %q", edited)
+  }
+}
+
+func TestFixCharsetErrorOffsetAfterBOM(t *testing.T) {
+  cfg := &config.ResolvedConfig{Charset: "utf-8"}
+
+  _, _, err := FixCharset("test.txt", []byte("\xEF\xBB\xBFab\xe9\n"), cfg)
+  if err == nil || !strings.Contains(err.Error(), "offset 5") {
+    t.Errorf("Expected an error at file offset 5, got %v", err)
+  }
+}
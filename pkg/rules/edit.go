@@ -0,0 +1,31 @@
+package rules
+
+import (
+  "fmt"
+  "sort"
+)
+
+// ApplyEdits returns content with the given edits applied. Edits may be given
+// in any order but must not overlap; content itself is not modified.
+func ApplyEdits(content []byte, edits []TextEdit) ([]byte, error) {
+  sorted := make([]TextEdit, len(edits))
+  copy(sorted, edits)
+  sort.SliceStable(sorted, func(i, j int) bool {
+    return sorted[i].Offset < sorted[j].Offset
+  })
+
+  result := make([]byte, 0, len(content))
+  last := 0
+  for _, edit := range sorted {
+    end := edit.Offset + edit.Length
+    if edit.Offset < last || end > len(content) {
+      return nil, fmt.Errorf("edit at offset %d (length %d) overlaps another edit or lies outside the content", edit.Offset, edit.Length)
+    }
+
+    result = append(result, content[last:edit.Offset]...)
+    result = append(result, edit.NewText...)
+    last = end
+  }
+
+  return append(result, content[last:]...), nil
+}
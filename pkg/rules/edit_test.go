@@ -0,0 +1,201 @@
+package rules
+
+import (
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+func TestApplyEdits(t *testing.T) {
+  content := []byte("hello world")
+
+  result, err := ApplyEdits(content, []TextEdit{
+    {Offset: 6, Length: 5, NewText: "there"},
+    {Offset: 0, Length: 0, NewText: "> "},
+  })
+  if err != nil {
+    t.Fatal(err)
+  }
+  if string(result) != "> hello there" {
+    t.Errorf("Expected %q, got %q", "> hello there", string(result))
+  }
+  if string(content) != "hello world" {
+    t.Errorf("ApplyEdits modified its input: %q", string(content))
+  }
+
+  if _, err := ApplyEdits(content, []TextEdit{{Offset: 0, Length: 5}, {Offset: 3, Length: 1}}); err == nil {
+    t.Error("Expected error for overlapping edits, but got none")
+  }
+}
+
+// TestEditsMatchFixers checks that applying the edits attached to every
+// violation produces the same content as running the rule's fixer
+func TestEditsMatchFixers(t *testing.T) {
+  trueVal := true
+  two := 2
+
+  tests := []struct {
+    name      string
+    content   string
+    cfg       config.ResolvedConfig
+    validator ValidatorFunc
+    fixer     FixerFunc
+  }{
+    {
+      name:      "trailing whitespace",
+      content:   "a  \nb\t\nc\n",
+      cfg:       config.ResolvedConfig{TrimTrailingWhitespace: &trueVal},
+      validator: ValidateTrimTrailingWhitespace,
+      fixer:     FixTrimTrailingWhitespace,
+    },
+    {
+      name:      "trailing whitespace before CRLF",
+      content:   "a \r\nb\r\n",
+      cfg:       config.ResolvedConfig{TrimTrailingWhitespace: &trueVal},
+      validator: ValidateTrimTrailingWhitespace,
+      fixer:     FixTrimTrailingWhitespace,
+    },
+    {
+      name:      "trailing whitespace before CR",
+      content:   "a \r\tb \r",
+      cfg:       config.ResolvedConfig{TrimTrailingWhitespace: &trueVal, EndOfLine: "cr"},
+      validator: ValidateTrimTrailingWhitespace,
+      fixer:     FixTrimTrailingWhitespace,
+    },
+    {
+      name:      "mixed line endings",
+      content:   "a\r\nb\rc\n",
+      cfg:       config.ResolvedConfig{EndOfLine: "lf"},
+      validator: ValidateEndOfLine,
+      fixer:     FixEndOfLine,
+    },
+    {
+      name:      "tabs to spaces",
+      content:   "\tx\n  \ty\nz\n",
+      cfg:       config.ResolvedConfig{IndentStyle: "space", IndentSize: &two},
+      validator: ValidateIndentStyle,
+      fixer:     FixIndentStyle,
+    },
+    {
+      name:      "tabs to spaces with CR line endings",
+      content:   "a \r\tb \r",
+      cfg:       config.ResolvedConfig{IndentStyle: "space", IndentSize: &two, EndOfLine: "cr"},
+      validator: ValidateIndentStyle,
+      fixer:     FixIndentStyle,
+    },
+    {
+      name:      "spaces to tabs",
+      content:   "    x\n   y\n",
+      cfg:       config.ResolvedConfig{IndentStyle: "tab", IndentSize: &two},
+      validator: ValidateIndentStyle,
+      fixer:     FixIndentStyle,
+    },
+    {
+      name:      "missing final newline",
+      content:   "abc",
+      cfg:       config.ResolvedConfig{InsertFinalNewline: &trueVal, EndOfLine: "crlf"},
+      validator: ValidateInsertFinalNewline,
+      fixer:     FixInsertFinalNewline,
+    },
+    {
+      name:      "wrong final newline",
+      content:   "abc\r\n",
+      cfg:       config.ResolvedConfig{InsertFinalNewline: &trueVal},
+      validator: ValidateInsertFinalNewline,
+      fixer:     FixInsertFinalNewline,
+    },
+    {
+      name:      "empty file final newline",
+      content:   "",
+      cfg:       config.ResolvedConfig{InsertFinalNewline: &trueVal},
+      validator: ValidateInsertFinalNewline,
+      fixer:     FixInsertFinalNewline,
+    },
+    {
+      name:      "unexpected UTF-8 BOM",
+      content:   "\xEF\xBB\xBFhello\n",
+      cfg:       config.ResolvedConfig{Charset: "utf-8"},
+      validator: ValidateCharset,
+      fixer:     FixCharset,
+    },
+    {
+      name:      "missing UTF-8 BOM",
+      content:   "héllo\n",
+      cfg:       config.ResolvedConfig{Charset: "utf-8-bom"},
+      validator: ValidateCharset,
+      fixer:     FixCharset,
+    },
+    {
+      name:      "Latin-1 bytes in UTF-8 file",
+      content:   "caf\xe9 cr\xe8me\n",
+      cfg:       config.ResolvedConfig{Charset: "utf-8"},
+      validator: ValidateCharset,
+      fixer:     FixCharset,
+    },
+    {
+      name:      "Latin-1 bytes in UTF-8-BOM file",
+      content:   "caf\xe9\n",
+      cfg:       config.ResolvedConfig{Charset: "utf-8-bom"},
+      validator: ValidateCharset,
+      fixer:     FixCharset,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      errs := tt.validator("test.txt", []byte(tt.content), &tt.cfg)
+      if len(errs) == 0 {
+        t.Fatal("Expected validation errors, but got none")
+      }
+
+      var edits []TextEdit
+      for _, err := range errs {
+        if len(err.Edits) == 0 {
+          t.Fatalf("Expected edits for %q, but got none", err.Message)
+        }
+        edits = append(edits, err.Edits...)
+      }
+
+      edited, err := ApplyEdits([]byte(tt.content), edits)
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      fixed, _, err := tt.fixer("test.txt", []byte(tt.content), &tt.cfg)
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      if string(edited) != string(fixed) {
+        t.Errorf("Edits produced %q, but fixer produced %q", string(edited), string(fixed))
+      }
+    })
+  }
+}
+
+func TestEditsOmittedWhenNotFixable(t *testing.T) {
+  tests := []struct {
+    name    string
+    content string
+    charset string
+  }{
+    {"UTF-16 BOM in UTF-8 file", "\xFF\xFEh\x00i\x00", "utf-8"},
+    {"UTF-8 BOM in Latin-1 file", "\xEF\xBB\xBFcaf\xc3\xa9", "latin1"},
+    {"mixed UTF-8 and Latin-1", "caf\xc3\xa9 cr\xe8me", "utf-8"},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      errs := ValidateCharset("test.txt", []byte(tt.content), &config.ResolvedConfig{Charset: tt.charset})
+      if len(errs) == 0 {
+        t.Fatal("Expected validation errors, but got none")
+      }
+
+      for _, err := range errs {
+        if len(err.Edits) > 0 {
+          t.Errorf("Expected no edits for %q, got %v", err.Message, err.Edits)
+        }
+      }
+    })
+  }
+}
@@ -8,12 +8,17 @@ import (
 )
 
 // ValidateEndOfLine checks if all line endings in the file match the configured style
-func ValidateEndOfLine(filePath string, content []byte, config *config.ResolvedConfig) *ValidationError {
+func ValidateEndOfLine(filePath string, content []byte, config *config.ResolvedConfig) []ValidationError {
   // Only validate if end_of_line is set
   if config.EndOfLine == "" {
     return nil
   }
 
+  // UTF-16 content is checked as decoded text
+  if !asciiCompatible(config) {
+    return validateDecoded(filePath, content, config, ValidateEndOfLine)
+  }
+
   if len(content) == 0 {
     return nil // Empty files are fine
   }
@@ -38,18 +43,19 @@ func ValidateEndOfLine(filePath string, content []byte, config *config.ResolvedC
 
   // Find all line endings in the file
   lineEndings := findLineEndings(content)
+  index := newLineIndex(content)
 
+  var errors []ValidationError
   for _, ending := range lineEndings {
     if !bytes.Equal(ending.bytes, expectedEnding) {
-      return &ValidationError{
-        FilePath: filePath,
-        Rule:     "end_of_line",
-        Message:  fmt.Sprintf("line %d uses %s but should use %s", ending.line, ending.name, expectedName),
-      }
+      err := index.violation(filePath, "end_of_line", ending.offset, len(ending.bytes),
+        fmt.Sprintf("line %d uses %s but should use %s", ending.line, ending.name, expectedName))
+      err.Edits = []TextEdit{index.edit(ending.offset, len(ending.bytes), string(expectedEnding))}
+      errors = append(errors, err)
     }
   }
 
-  return nil
+  return errors
 }
 
 // FixEndOfLine converts all line endings to the configured style
@@ -59,6 +65,11 @@ func FixEndOfLine(filePath string, content []byte, config *config.ResolvedConfig
     return content, false, nil
   }
 
+  // UTF-16 content is fixed as decoded text
+  if !asciiCompatible(config) {
+    return fixDecoded(filePath, content, config, FixEndOfLine)
+  }
+
   if len(content) == 0 {
     return content, false, nil
   }
@@ -88,9 +99,10 @@ func FixEndOfLine(filePath string, content []byte, config *config.ResolvedConfig
 
 // LineEnding represents a line ending found in the file
 type LineEnding struct {
-  bytes []byte
-  name  string
-  line  int
+  bytes  []byte
+  name   string
+  line   int
+  offset int
 }
 
 // findLineEndings finds all line endings in the content and their positions
@@ -103,26 +115,29 @@ func findLineEndings(content []byte) []LineEnding {
       if i+1 < len(content) && content[i+1] == '\n' {
         // CRLF
         endings = append(endings, LineEnding{
-          bytes: []byte("\r\n"),
-          name:  "CRLF (\\r\\n)",
-          line:  lineNum,
+          bytes:  []byte("\r\n"),
+          name:   "CRLF (\\r\\n)",
+          line:   lineNum,
+          offset: i,
         })
         i++ // Skip the \n
       } else {
         // CR only
         endings = append(endings, LineEnding{
-          bytes: []byte("\r"),
-          name:  "CR (\\r)",
-          line:  lineNum,
+          bytes:  []byte("\r"),
+          name:   "CR (\\r)",
+          line:   lineNum,
+          offset: i,
         })
       }
       lineNum++
     } else if content[i] == '\n' {
       // LF only
       endings = append(endings, LineEnding{
-        bytes: []byte("\n"),
-        name:  "LF (\\n)",
-        line:  lineNum,
+        bytes:  []byte("\n"),
+        name:   "LF (\\n)",
+        line:   lineNum,
+        offset: i,
       })
       lineNum++
     }
@@ -51,19 +51,34 @@ func TestValidateEndOfLine(t *testing.T) {
         EndOfLine: tt.endOfLine,
       }
 
-      err := ValidateEndOfLine("test.go", []byte(tt.content), cfg)
+      errs := ValidateEndOfLine("test.go", []byte(tt.content), cfg)
 
-      if tt.wantError && err == nil {
+      if tt.wantError && len(errs) == 0 {
         t.Error("Expected validation error, but got none")
       }
 
-      if !tt.wantError && err != nil {
-        t.Errorf("Expected no validation error, but got: %v", err)
+      if !tt.wantError && len(errs) > 0 {
+        t.Errorf("Expected no validation error, but got: %v", errs)
       }
     })
   }
 }
 
+func TestValidateEndOfLineReportsEveryLine(t *testing.T) {
+  cfg := &config.ResolvedConfig{EndOfLine: "lf"}
+
+  errs := ValidateEndOfLine("test.go", []byte("a\r\nb\nc\rd\r\n"), cfg)
+  if len(errs) != 3 {
+    t.Fatalf("Expected 3 validation errors, got %d: %v", len(errs), errs)
+  }
+
+  for i, line := range []int{1, 3, 4} {
+    if errs[i].Line != line {
+      t.Errorf("Expected error %d on line %d, got %d", i, line, errs[i].Line)
+    }
+  }
+}
+
 func TestFixEndOfLine(t *testing.T) {
   tests := []struct {
     name            string
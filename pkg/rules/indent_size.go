@@ -0,0 +1,71 @@
+package rules
+
+import (
+  "fmt"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+// ValidateIndentSize checks if each indented line's leading whitespace width is a multiple of indent_size.
+//
+// Tabs are expanded to the next tab_width stop before measuring. When
+// indent_size_tolerance is "continuation", a line that is indented deeper than
+// the last line on the indent_size grid is treated as a continuation or
+// alignment line and accepted even if its width is not a multiple.
+func ValidateIndentSize(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError {
+  size := indentSize(cfg)
+
+  // Only validate if indent_size is set
+  if size == 0 {
+    return nil
+  }
+
+  // UTF-16 content is checked as decoded text
+  if !asciiCompatible(cfg) {
+    return validateDecoded(filePath, content, cfg, ValidateIndentSize)
+  }
+
+  if len(content) == 0 {
+    return nil // Empty files are fine
+  }
+
+  width := tabWidth(cfg)
+  index := newLineIndex(content)
+  base := 0 // Width of the last line that was on the indent_size grid
+
+  var errors []ValidationError
+  for _, line := range splitLines(content) {
+    // Whitespace-only lines are left to trim_trailing_whitespace
+    if isBlankLine(line.text) {
+      continue
+    }
+
+    indent := leadingWhitespace(line.text)
+    columns := indentWidth(indent, width)
+    if columns%size == 0 {
+      base = columns
+      continue
+    }
+
+    if cfg.IndentSizeTolerance == "continuation" && columns > base {
+      continue
+    }
+
+    errors = append(errors, index.violation(filePath, "indent_size", line.offset, len(indent),
+      fmt.Sprintf("line %d: indentation width %d is not a multiple of %d", line.number, columns, size)))
+  }
+
+  return errors
+}
+
+// indentSize returns the configured indentation width, or 0 if indent_size is not set.
+// An indent_size of "tab" falls back to tab_width.
+func indentSize(cfg *config.ResolvedConfig) int {
+  if cfg.IndentSize != nil {
+    return *cfg.IndentSize
+  }
+  if cfg.IndentSizeTab {
+    return tabWidth(cfg)
+  }
+  return 0
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+func TestValidateIndentSize(t *testing.T) {
+  two := 2
+  four := 4
+  eight := 8
+
+  tests := []struct {
+    name          string
+    content       string
+    indentSize    *int
+    indentSizeTab bool
+    tabWidth      *int
+    tolerance     string
+    wantError     bool
+  }{
+    {
+      name:       "multiples of indent_size",
+      content:    "a:\n  b:\n    c: 1\n",
+      indentSize: &two,
+      wantError:  false,
+    },
+    {
+      name:       "odd indentation",
+      content:    "a:\n  b:\n   c: 1\n",
+      indentSize: &two,
+      wantError:  true,
+    },
+    {
+      name:       "tabs expand by tab_width",
+      content:    "func main() {\n\treturn\n}\n",
+      indentSize: &four,
+      tabWidth:   &eight,
+      wantError:  false,
+    },
+    {
+      name:       "tab plus spaces off grid",
+      content:    "func main() {\n\t  return\n}\n",
+      indentSize: &four,
+      wantError:  true,
+    },
+    {
+      name:          "indent_size = tab uses tab_width",
+      content:       "a\n        b\n",
+      indentSizeTab: true,
+      tabWidth:      &eight,
+      wantError:     false,
+    },
+    {
+      name:          "indent_size = tab with off grid spaces",
+      content:       "a\n    b\n",
+      indentSizeTab: true,
+      tabWidth:      &eight,
+      wantError:     true,
+    },
+    {
+      name:       "continuation on grid in strict mode",
+      content:    "foo(a,\n    b)\n",
+      indentSize: &four,
+      tolerance:  "strict",
+      wantError:  false,
+    },
+    {
+      name:       "aligned continuation rejected in strict mode",
+      content:    "    result = compute(a,\n                     b);\n",
+      indentSize: &four,
+      wantError:  true,
+    },
+    {
+      name:       "aligned continuation accepted with tolerance",
+      content:    "    result = compute(a,\n                     b,\n                     c);\n    return;\n",
+      indentSize: &four,
+      tolerance:  "continuation",
+      wantError:  false,
+    },
+    {
+      name:       "block comment alignment accepted with tolerance",
+      content:    "\t/*\n\t * comment\n\t */\n",
+      indentSize: &four,
+      tolerance:  "continuation",
+      wantError:  false,
+    },
+    {
+      name:       "shallower off grid line rejected with tolerance",
+      content:    "        x\n      y\n",
+      indentSize: &four,
+      tolerance:  "continuation",
+      wantError:  true,
+    },
+    {
+      name:      "indent_size unset",
+      content:   "a\n   b\n",
+      wantError: false,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      cfg := &config.ResolvedConfig{
+        IndentSize:          tt.indentSize,
+        IndentSizeTab:       tt.indentSizeTab,
+        TabWidth:            tt.tabWidth,
+        IndentSizeTolerance: tt.tolerance,
+      }
+
+      errs := ValidateIndentSize("test.go", []byte(tt.content), cfg)
+
+      if tt.wantError && len(errs) == 0 {
+        t.Error("Expected validation error, but got none")
+      }
+
+      if !tt.wantError && len(errs) > 0 {
+        t.Errorf("Expected no validation error, but got: %v", errs)
+      }
+    })
+  }
+}
@@ -0,0 +1,157 @@
+package rules
+
+import (
+  "bytes"
+  "fmt"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+// defaultTabWidth is the tab width used when neither tab_width nor indent_size is set
+const defaultTabWidth = 4
+
+// ValidateIndentStyle checks if all lines are indented with the configured character
+func ValidateIndentStyle(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError {
+  // Only validate if indent_style is set
+  if cfg.IndentStyle == "" {
+    return nil
+  }
+
+  // UTF-16 content is checked as decoded text
+  if !asciiCompatible(cfg) {
+    return validateDecoded(filePath, content, cfg, ValidateIndentStyle)
+  }
+
+  if len(content) == 0 {
+    return nil // Empty files are fine
+  }
+
+  width := tabWidth(cfg)
+  index := newLineIndex(content)
+
+  var errors []ValidationError
+  for _, line := range splitLines(content) {
+    indent := leadingWhitespace(line.text)
+
+    // Whitespace-only lines are left to trim_trailing_whitespace
+    if len(indent) == 0 || isBlankLine(line.text) {
+      continue
+    }
+
+    expected := canonicalIndent(indent, cfg.IndentStyle, width)
+    if bytes.Equal(indent, expected) {
+      continue
+    }
+
+    var message string
+    if cfg.IndentStyle == "tab" {
+      message = fmt.Sprintf("line %d uses spaces but should use tabs", line.number)
+    } else {
+      message = fmt.Sprintf("line %d uses tabs but should use spaces", line.number)
+    }
+
+    err := index.violation(filePath, "indent_style", line.offset, len(indent), message)
+    err.Edits = []TextEdit{index.edit(line.offset, len(indent), string(expected))}
+    errors = append(errors, err)
+  }
+
+  return errors
+}
+
+// FixIndentStyle rewrites leading indentation to use the configured character
+func FixIndentStyle(filePath string, content []byte, cfg *config.ResolvedConfig) ([]byte, bool, error) {
+  // Only fix if indent_style is set
+  if cfg.IndentStyle == "" {
+    return content, false, nil
+  }
+
+  // UTF-16 content is fixed as decoded text
+  if !asciiCompatible(cfg) {
+    return fixDecoded(filePath, content, cfg, FixIndentStyle)
+  }
+
+  if len(content) == 0 {
+    return content, false, nil
+  }
+
+  width := tabWidth(cfg)
+
+  // Rebuild the content line by line, keeping each line's original ending
+  fixed := make([]byte, 0, len(content))
+  hasChanges := false
+
+  for _, line := range splitLines(content) {
+    indent := leadingWhitespace(line.text)
+    expected := indent
+    if len(indent) > 0 && !isBlankLine(line.text) {
+      expected = canonicalIndent(indent, cfg.IndentStyle, width)
+    }
+
+    if !bytes.Equal(indent, expected) {
+      hasChanges = true
+    }
+    fixed = append(fixed, expected...)
+    fixed = append(fixed, line.text[len(indent):]...)
+    fixed = append(fixed, line.ending...)
+  }
+
+  if !hasChanges {
+    return content, false, nil
+  }
+
+  return fixed, true, nil
+}
+
+// tabWidth returns the display width of a tab character.
+// Per the EditorConfig spec tab_width defaults to indent_size when unset.
+func tabWidth(cfg *config.ResolvedConfig) int {
+  if cfg.TabWidth != nil {
+    return *cfg.TabWidth
+  }
+  if cfg.IndentSize != nil {
+    return *cfg.IndentSize
+  }
+  return defaultTabWidth
+}
+
+// leadingWhitespace returns the run of spaces and tabs at the start of a line
+func leadingWhitespace(line []byte) []byte {
+  i := 0
+  for i < len(line) && isWhitespace(line[i]) {
+    i++
+  }
+  return line[:i]
+}
+
+// isBlankLine reports whether a line contains only whitespace (ignoring a trailing CR)
+func isBlankLine(line []byte) bool {
+  return len(bytes.TrimRight(line, " \t\r")) == 0
+}
+
+// indentWidth returns the display width of an indentation, expanding tabs to
+// the next multiple of width
+func indentWidth(indent []byte, width int) int {
+  column := 0
+  for _, b := range indent {
+    if b == '\t' {
+      column += width - column%width
+    } else {
+      column++
+    }
+  }
+  return column
+}
+
+// canonicalIndent returns the indentation that renders at the same width as
+// indent using the given style. Tab-style indentation keeps any remainder
+// narrower than a tab as spaces so alignment is preserved.
+func canonicalIndent(indent []byte, style string, width int) []byte {
+  columns := indentWidth(indent, width)
+
+  if style == "tab" {
+    result := bytes.Repeat([]byte("\t"), columns/width)
+    return append(result, bytes.Repeat([]byte(" "), columns%width)...)
+  }
+
+  return bytes.Repeat([]byte(" "), columns)
+}
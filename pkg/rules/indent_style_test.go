@@ -0,0 +1,171 @@
+package rules
+
+import (
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+func TestValidateIndentStyle(t *testing.T) {
+  tests := []struct {
+    name        string
+    content     string
+    indentStyle string
+    wantError   bool
+  }{
+    {
+      name:        "spaces when expecting spaces",
+      content:     "func main() {\n    return\n}\n",
+      indentStyle: "space",
+      wantError:   false,
+    },
+    {
+      name:        "tabs when expecting spaces",
+      content:     "func main() {\n\treturn\n}\n",
+      indentStyle: "space",
+      wantError:   true,
+    },
+    {
+      name:        "tabs when expecting tabs",
+      content:     "func main() {\n\treturn\n}\n",
+      indentStyle: "tab",
+      wantError:   false,
+    },
+    {
+      name:        "spaces when expecting tabs",
+      content:     "func main() {\n    return\n}\n",
+      indentStyle: "tab",
+      wantError:   true,
+    },
+    {
+      name:        "tab followed by alignment spaces",
+      content:     "/*\n\t * comment\n\t */\n",
+      indentStyle: "tab",
+      wantError:   false,
+    },
+    {
+      name:        "space before tab when expecting tabs",
+      content:     "func main() {\n \treturn\n}\n",
+      indentStyle: "tab",
+      wantError:   true,
+    },
+    {
+      name:        "whitespace-only line is ignored",
+      content:     "a\n\t\nb\n",
+      indentStyle: "space",
+      wantError:   false,
+    },
+    {
+      name:        "indent_style unset",
+      content:     "\treturn\n    return\n",
+      indentStyle: "",
+      wantError:   false,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      cfg := &config.ResolvedConfig{
+        IndentStyle: tt.indentStyle,
+      }
+
+      errs := ValidateIndentStyle("test.go", []byte(tt.content), cfg)
+
+      if tt.wantError && len(errs) == 0 {
+        t.Error("Expected validation error, but got none")
+      }
+
+      if !tt.wantError && len(errs) > 0 {
+        t.Errorf("Expected no validation error, but got: %v", errs)
+      }
+    })
+  }
+}
+
+func TestFixIndentStyle(t *testing.T) {
+  four := 4
+  two := 2
+
+  tests := []struct {
+    name            string
+    content         string
+    indentStyle     string
+    indentSize      *int
+    tabWidth        *int
+    expectedContent string
+    expectFixed     bool
+  }{
+    {
+      name:            "tabs to spaces using indent_size",
+      content:         "func main() {\n\tif x {\n\t\treturn\n\t}\n}\n",
+      indentStyle:     "space",
+      indentSize:      &four,
+      expectedContent: "func main() {\n    if x {\n        return\n    }\n}\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "tabs to spaces prefers tab_width",
+      content:         "\treturn\n",
+      indentStyle:     "space",
+      indentSize:      &four,
+      tabWidth:        &two,
+      expectedContent: "  return\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "spaces to tabs keeps alignment remainder",
+      content:         "func main() {\n    return\n      x\n}\n",
+      indentStyle:     "tab",
+      indentSize:      &four,
+      expectedContent: "func main() {\n\treturn\n\t  x\n}\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "preserves CRLF line endings",
+      content:         "a\r\n\tb\r\n",
+      indentStyle:     "space",
+      indentSize:      &two,
+      expectedContent: "a\r\n  b\r\n",
+      expectFixed:     true,
+    },
+    {
+      name:            "preserves CR line endings",
+      content:         "a\r\tb \r",
+      indentStyle:     "space",
+      indentSize:      &two,
+      expectedContent: "a\r  b \r",
+      expectFixed:     true,
+    },
+    {
+      name:            "already correct",
+      content:         "a\n\tb\n",
+      indentStyle:     "tab",
+      indentSize:      &four,
+      expectedContent: "a\n\tb\n",
+      expectFixed:     false,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      cfg := &config.ResolvedConfig{
+        IndentStyle: tt.indentStyle,
+        IndentSize:  tt.indentSize,
+        TabWidth:    tt.tabWidth,
+      }
+
+      newContent, fixed, err := FixIndentStyle("test.go", []byte(tt.content), cfg)
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      if fixed != tt.expectFixed {
+        t.Errorf("Expected fixed=%v, got %v", tt.expectFixed, fixed)
+      }
+
+      if string(newContent) != tt.expectedContent {
+        t.Errorf("Expected content %q, got %q", tt.expectedContent, string(newContent))
+      }
+    })
+  }
+}
@@ -8,53 +8,57 @@ import (
 )
 
 // ValidateInsertFinalNewline checks if the file ends with the appropriate newline character(s)
-func ValidateInsertFinalNewline(filePath string, content []byte, cfg *config.ResolvedConfig) *ValidationError {
+func ValidateInsertFinalNewline(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError {
   // Only validate if insert_final_newline is explicitly set to true
   if cfg.InsertFinalNewline == nil || !*cfg.InsertFinalNewline {
     return nil
   }
 
+  // UTF-16 content is checked as decoded text
+  if !asciiCompatible(cfg) {
+    return validateDecoded(filePath, content, cfg, ValidateInsertFinalNewline)
+  }
+
+  expectedEnding, expectedBytes := finalNewline(cfg)
+
   if len(content) == 0 {
     // Empty files should end with a newline if insert_final_newline is true
-    return &ValidationError{
-      FilePath: filePath,
-      Rule:     "insert_final_newline",
-      Message:  "empty file should end with a newline",
-    }
+    index := newLineIndex(content)
+    err := index.violation(filePath, "insert_final_newline", 0, 0, "empty file should end with a newline")
+    err.Edits = []TextEdit{index.edit(0, 0, string(expectedBytes))}
+    return []ValidationError{err}
   }
 
   // Determine what the file actually ends with
   lastChar := content[len(content)-1]
+  index := newLineIndex(content)
   var actualEnding string
+  var endingLen int
 
   if len(content) >= 2 && content[len(content)-2] == '\r' && lastChar == '\n' {
     actualEnding = "crlf"
+    endingLen = 2
   } else if lastChar == '\r' {
     actualEnding = "cr"
+    endingLen = 1
   } else if lastChar == '\n' {
     actualEnding = "lf"
+    endingLen = 1
   } else {
     // File doesn't end with any recognized line ending
-    return &ValidationError{
-      FilePath: filePath,
-      Rule:     "insert_final_newline",
-      Message:  fmt.Sprintf("file should end with %s, but ends with character '%c' (0x%02x)", getEndOfLineDescription(cfg.EndOfLine), lastChar, lastChar),
-    }
-  }
-
-  // Determine expected line ending
-  expectedEnding := cfg.EndOfLine
-  if expectedEnding == "" {
-    expectedEnding = "lf" // Default to LF
+    // Report the point where the newline should be inserted
+    err := index.violation(filePath, "insert_final_newline", len(content), 0,
+      fmt.Sprintf("file should end with %s, but ends with character '%c' (0x%02x)", getEndOfLineDescription(cfg.EndOfLine), lastChar, lastChar))
+    err.Edits = []TextEdit{index.edit(len(content), 0, string(expectedBytes))}
+    return []ValidationError{err}
   }
 
   // Check if actual matches expected
   if actualEnding != expectedEnding {
-    return &ValidationError{
-      FilePath: filePath,
-      Rule:     "insert_final_newline",
-      Message:  fmt.Sprintf("file should end with %s, but ends with %s", getEndOfLineDescription(expectedEnding), getEndOfLineDescription(actualEnding)),
-    }
+    err := index.violation(filePath, "insert_final_newline", len(content)-endingLen, endingLen,
+      fmt.Sprintf("file should end with %s, but ends with %s", getEndOfLineDescription(expectedEnding), getEndOfLineDescription(actualEnding)))
+    err.Edits = []TextEdit{index.edit(len(content)-endingLen, endingLen, string(expectedBytes))}
+    return []ValidationError{err}
   }
 
   return nil
@@ -67,21 +71,12 @@ func FixInsertFinalNewline(filePath string, content []byte, cfg *config.Resolved
     return content, false, nil
   }
 
-  // Determine expected line ending
-  expectedEnding := cfg.EndOfLine
-  if expectedEnding == "" {
-    expectedEnding = "lf" // Default to LF
+  // UTF-16 content is fixed as decoded text
+  if !asciiCompatible(cfg) {
+    return fixDecoded(filePath, content, cfg, FixInsertFinalNewline)
   }
 
-  var expectedBytes []byte
-  switch expectedEnding {
-  case "crlf":
-    expectedBytes = []byte("\r\n")
-  case "cr":
-    expectedBytes = []byte("\r")
-  default: // "lf"
-    expectedBytes = []byte("\n")
-  }
+  expectedEnding, expectedBytes := finalNewline(cfg)
 
   // Handle empty files
   if len(content) == 0 {
@@ -93,30 +88,33 @@ func FixInsertFinalNewline(filePath string, content []byte, cfg *config.Resolved
   var needsFix bool
   var newContent []byte
 
+  // The slices below are capped at their length so that appending copies
+  // rather than overwriting the caller's content
+
   if len(content) >= 2 && content[len(content)-2] == '\r' && lastChar == '\n' {
     // File ends with CRLF
     if expectedEnding != "crlf" {
       // Remove CRLF and add correct ending
-      newContent = append(content[:len(content)-2], expectedBytes...)
+      newContent = append(content[:len(content)-2:len(content)-2], expectedBytes...)
       needsFix = true
     }
   } else if lastChar == '\r' {
     // File ends with CR
     if expectedEnding != "cr" {
       // Remove CR and add correct ending
-      newContent = append(content[:len(content)-1], expectedBytes...)
+      newContent = append(content[:len(content)-1:len(content)-1], expectedBytes...)
       needsFix = true
     }
   } else if lastChar == '\n' {
     // File ends with LF
     if expectedEnding != "lf" {
       // Remove LF and add correct ending
-      newContent = append(content[:len(content)-1], expectedBytes...)
+      newContent = append(content[:len(content)-1:len(content)-1], expectedBytes...)
       needsFix = true
     }
   } else {
     // File doesn't end with any line ending - add the expected one
-    newContent = append(content, expectedBytes...)
+    newContent = append(content[:len(content):len(content)], expectedBytes...)
     needsFix = true
   }
 
@@ -127,6 +125,19 @@ func FixInsertFinalNewline(filePath string, content []byte, cfg *config.Resolved
   return content, false, nil
 }
 
+// finalNewline returns the name and bytes of the line ending a file should end
+// with, following end_of_line and defaulting to LF
+func finalNewline(cfg *config.ResolvedConfig) (string, []byte) {
+  switch cfg.EndOfLine {
+  case "crlf":
+    return "crlf", []byte("\r\n")
+  case "cr":
+    return "cr", []byte("\r")
+  default:
+    return "lf", []byte("\n")
+  }
+}
+
 // getEndOfLineDescription returns a human-readable description of line ending
 func getEndOfLineDescription(endOfLine string) string {
   switch endOfLine {
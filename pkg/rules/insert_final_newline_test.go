@@ -65,14 +65,14 @@ func TestValidateInsertFinalNewline(t *testing.T) {
         InsertFinalNewline: &tt.insertFinalNewline,
       }
 
-      err := ValidateInsertFinalNewline("test.go", []byte(tt.content), resolvedConfig)
+      errs := ValidateInsertFinalNewline("test.go", []byte(tt.content), resolvedConfig)
 
-      if tt.wantError && err == nil {
+      if tt.wantError && len(errs) == 0 {
         t.Error("Expected validation error, but got none")
       }
 
-      if !tt.wantError && err != nil {
-        t.Errorf("Expected no validation error, but got: %v", err)
+      if !tt.wantError && len(errs) > 0 {
+        t.Errorf("Expected no validation error, but got: %v", errs)
       }
     })
   }
@@ -128,11 +128,16 @@ func TestFixInsertFinalNewline(t *testing.T) {
         InsertFinalNewline: &tt.insertFinalNewline,
       }
 
-      newContent, fixed, err := FixInsertFinalNewline("test.go", []byte(tt.content), resolvedConfig)
+      content := []byte(tt.content)
+      newContent, fixed, err := FixInsertFinalNewline("test.go", content, resolvedConfig)
       if err != nil {
         t.Fatal(err)
       }
 
+      if string(content) != tt.content {
+        t.Errorf("Input content was modified to %q", string(content))
+      }
+
       if fixed != tt.expectFixed {
         t.Errorf("Expected fixed=%v, got %v", tt.expectFixed, fixed)
       }
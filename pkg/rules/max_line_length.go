@@ -0,0 +1,88 @@
+package rules
+
+import (
+  "fmt"
+  "unicode"
+  "unicode/utf8"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+  "golang.org/x/text/width"
+)
+
+// ValidateMaxLineLength checks if any line's display width exceeds max_line_length.
+//
+// Tabs expand to the next tab_width stop, East Asian wide and fullwidth
+// characters count as two columns and combining marks count as zero.
+func ValidateMaxLineLength(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError {
+  // Only validate if max_line_length is set
+  if cfg.MaxLineLength == nil {
+    return nil
+  }
+
+  // UTF-16 content is checked as decoded text
+  if !asciiCompatible(cfg) {
+    return validateDecoded(filePath, content, cfg, ValidateMaxLineLength)
+  }
+
+  if len(content) == 0 {
+    return nil // Empty files are fine
+  }
+
+  maxLength := *cfg.MaxLineLength
+  tab := tabWidth(cfg)
+  index := newLineIndex(content)
+
+  var errors []ValidationError
+  for _, line := range splitLines(content) {
+    length, overflow := measureLine(line.text, tab, maxLength)
+    if length > maxLength {
+      // The violation covers the part of the line past the limit
+      errors = append(errors, index.violation(filePath, "max_line_length", line.offset+overflow, len(line.text)-overflow,
+        fmt.Sprintf("line %d is %d columns wide, exceeds maximum of %d", line.number, length, maxLength)))
+    }
+  }
+
+  return errors
+}
+
+// measureLine returns the number of columns a line occupies when rendered,
+// and the byte offset of the first character that extends past limit
+// (or the length of the line if none does)
+func measureLine(line []byte, tab int, limit int) (int, int) {
+  column := 0
+  overflow := len(line)
+
+  for i := 0; i < len(line); {
+    r, size := utf8.DecodeRune(line[i:])
+
+    switch {
+    case r == '\t':
+      column += tab - column%tab
+    case r == utf8.RuneError && size == 1:
+      column++ // Count invalid bytes as a single column
+    default:
+      column += runeWidth(r)
+    }
+
+    if column > limit && overflow == len(line) {
+      overflow = i
+    }
+    i += size
+  }
+
+  return column, overflow
+}
+
+// runeWidth returns the display width of a single rune
+func runeWidth(r rune) int {
+  if unicode.In(r, unicode.Mn, unicode.Me) || r == '\u200b' || r == '\u200d' || r == '\ufeff' {
+    return 0
+  }
+
+  switch width.LookupRune(r).Kind() {
+  case width.EastAsianWide, width.EastAsianFullwidth:
+    return 2
+  default:
+    return 1
+  }
+}
@@ -0,0 +1,118 @@
+package rules
+
+import (
+  "strings"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+func TestValidateMaxLineLength(t *testing.T) {
+  four := 4
+  eight := 8
+
+  tests := []struct {
+    name          string
+    content       string
+    maxLineLength int
+    tabWidth      *int
+    wantError     bool
+  }{
+    {
+      name:          "within limit",
+      content:       "12345\n123\n",
+      maxLineLength: 5,
+      wantError:     false,
+    },
+    {
+      name:          "exceeds limit",
+      content:       "123456\n",
+      maxLineLength: 5,
+      wantError:     true,
+    },
+    {
+      name:          "CRLF not counted",
+      content:       "12345\r\n",
+      maxLineLength: 5,
+      wantError:     false,
+    },
+    {
+      name:          "tab expands by tab_width",
+      content:       "\t1234\n",
+      maxLineLength: 10,
+      tabWidth:      &eight,
+      wantError:     true,
+    },
+    {
+      name:          "tab expands to next stop",
+      content:       "12\t34\n",
+      maxLineLength: 6,
+      tabWidth:      &four,
+      wantError:     false,
+    },
+    {
+      name:          "multibyte characters count once",
+      content:       "héllo\n",
+      maxLineLength: 5,
+      wantError:     false,
+    },
+    {
+      name:          "wide characters count twice",
+      content:       "日本語\n",
+      maxLineLength: 5,
+      wantError:     true,
+    },
+    {
+      name:          "combining marks count zero",
+      content:       "e\u0301e\u0301e\u0301\n",
+      maxLineLength: 3,
+      wantError:     false,
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      cfg := &config.ResolvedConfig{
+        MaxLineLength: &tt.maxLineLength,
+        TabWidth:      tt.tabWidth,
+      }
+
+      errs := ValidateMaxLineLength("test.go", []byte(tt.content), cfg)
+
+      if tt.wantError && len(errs) == 0 {
+        t.Error("Expected validation error, but got none")
+      }
+
+      if !tt.wantError && len(errs) > 0 {
+        t.Errorf("Expected no validation error, but got: %v", errs)
+      }
+    })
+  }
+}
+
+func TestValidateMaxLineLengthReportsEveryLine(t *testing.T) {
+  maxLineLength := 3
+  cfg := &config.ResolvedConfig{MaxLineLength: &maxLineLength}
+
+  errs := ValidateMaxLineLength("test.go", []byte("1234\n12\n12345\n"), cfg)
+  if len(errs) != 2 {
+    t.Fatalf("Expected 2 validation errors, got %d: %v", len(errs), errs)
+  }
+
+  wants := []struct {
+    line    int
+    message string
+  }{
+    {1, "line 1 is 4 columns wide"},
+    {3, "line 3 is 5 columns wide"},
+  }
+
+  for i, want := range wants {
+    if errs[i].Line != want.line {
+      t.Errorf("Expected error %d on line %d, got %d", i, want.line, errs[i].Line)
+    }
+    if !strings.Contains(errs[i].Message, want.message) {
+      t.Errorf("Expected message to contain %q, got %q", want.message, errs[i].Message)
+    }
+  }
+}
@@ -0,0 +1,110 @@
+package rules
+
+import (
+  "sort"
+)
+
+// textLine is a single line of file content
+type textLine struct {
+  number int    // 1-based line number
+  offset int    // Byte offset of the first character of the line
+  text   []byte // Line content without its line ending
+  ending []byte // Line ending (LF, CRLF or CR), empty for the last line
+}
+
+// splitLines splits content into lines, recognising LF, CRLF and CR line endings.
+// Content ending in a line break yields a final empty line.
+func splitLines(content []byte) []textLine {
+  var lines []textLine
+  start := 0
+
+  for i := 0; i < len(content); i++ {
+    var endingLen int
+    switch {
+    case content[i] == '\n':
+      endingLen = 1
+    case content[i] == '\r' && i+1 < len(content) && content[i+1] == '\n':
+      endingLen = 2
+    case content[i] == '\r':
+      endingLen = 1
+    default:
+      continue
+    }
+
+    lines = append(lines, textLine{
+      number: len(lines) + 1,
+      offset: start,
+      text:   content[start:i],
+      ending: content[i : i+endingLen],
+    })
+    i += endingLen - 1
+    start = i + 1
+  }
+
+  return append(lines, textLine{
+    number: len(lines) + 1,
+    offset: start,
+    text:   content[start:],
+  })
+}
+
+// lineIndex maps byte offsets in content to line and column positions
+type lineIndex struct {
+  lines  []textLine
+  starts []int // Byte offset at which each line begins
+}
+
+// newLineIndex builds a lineIndex for content
+func newLineIndex(content []byte) *lineIndex {
+  lines := splitLines(content)
+  starts := make([]int, len(lines))
+  for i, line := range lines {
+    starts[i] = line.offset
+  }
+  return &lineIndex{lines: lines, starts: starts}
+}
+
+// position returns the 1-based line and byte column of offset
+func (idx *lineIndex) position(offset int) (int, int) {
+  // Find the last line starting at or before offset
+  line := sort.Search(len(idx.starts), func(i int) bool {
+    return idx.starts[i] > offset
+  })
+  return line, offset - idx.starts[line-1] + 1
+}
+
+// violation builds a ValidationError covering length bytes starting at offset
+func (idx *lineIndex) violation(filePath, rule string, offset, length int, message string) ValidationError {
+  line, column := idx.position(offset)
+  endLine, endColumn := idx.position(offset + length)
+
+  return ValidationError{
+    FilePath:   filePath,
+    Rule:       rule,
+    Message:    message,
+    Line:       line,
+    Column:     column,
+    EndLine:    endLine,
+    EndColumn:  endColumn,
+    Offset:     offset,
+    Length:     length,
+    LineText:   string(idx.lines[line-1].text),
+    LineEnding: string(idx.lines[line-1].ending),
+  }
+}
+
+// edit builds a TextEdit replacing length bytes starting at offset with newText
+func (idx *lineIndex) edit(offset, length int, newText string) TextEdit {
+  line, column := idx.position(offset)
+  endLine, endColumn := idx.position(offset + length)
+
+  return TextEdit{
+    Line:      line,
+    Column:    column,
+    EndLine:   endLine,
+    EndColumn: endColumn,
+    Offset:    offset,
+    Length:    length,
+    NewText:   newText,
+  }
+}
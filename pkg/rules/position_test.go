@@ -0,0 +1,71 @@
+package rules
+
+import (
+  "testing"
+)
+
+func TestLineIndexViolation(t *testing.T) {
+  tests := []struct {
+    name          string
+    content       string
+    offset        int
+    length        int
+    wantLine      int
+    wantColumn    int
+    wantEndLine   int
+    wantEndColumn int
+    wantLineText  string
+  }{
+    {"start of file", "abc\n", 0, 1, 1, 1, 1, 2, "abc"},
+    {"second line", "abc\ndef\n", 5, 2, 2, 2, 2, 4, "def"},
+    {"line ending", "abc\ndef\n", 3, 1, 1, 4, 2, 1, "abc"},
+    {"after CRLF", "a\r\nb", 3, 1, 2, 1, 2, 2, "b"},
+    {"after CR", "a\rb", 2, 1, 2, 1, 2, 2, "b"},
+    {"zero length at end of file", "abc", 3, 0, 1, 4, 1, 4, "abc"},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      err := newLineIndex([]byte(tt.content)).violation("test.txt", "rule", tt.offset, tt.length, "message")
+
+      if err.Line != tt.wantLine || err.Column != tt.wantColumn {
+        t.Errorf("Expected start %d:%d, got %d:%d", tt.wantLine, tt.wantColumn, err.Line, err.Column)
+      }
+
+      if err.EndLine != tt.wantEndLine || err.EndColumn != tt.wantEndColumn {
+        t.Errorf("Expected end %d:%d, got %d:%d", tt.wantEndLine, tt.wantEndColumn, err.EndLine, err.EndColumn)
+      }
+
+      if err.LineText != tt.wantLineText {
+        t.Errorf("Expected line text %q, got %q", tt.wantLineText, err.LineText)
+      }
+
+      if err.Offset != tt.offset || err.Length != tt.length {
+        t.Errorf("Expected offset %d length %d, got %d and %d", tt.offset, tt.length, err.Offset, err.Length)
+      }
+    })
+  }
+}
+
+func TestLineIndexViolationLineEnding(t *testing.T) {
+  tests := []struct {
+    name    string
+    content string
+    offset  int
+    want    string
+  }{
+    {"LF", "abc\ndef", 1, "\n"},
+    {"CRLF", "abc\r\ndef", 3, "\r\n"},
+    {"CR", "abc\rdef", 0, "\r"},
+    {"last line", "abc\ndef", 5, ""},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      err := newLineIndex([]byte(tt.content)).violation("test.txt", "rule", tt.offset, 1, "message")
+      if err.LineEnding != tt.want {
+        t.Errorf("Expected line ending %q, got %q", tt.want, err.LineEnding)
+      }
+    })
+  }
+}
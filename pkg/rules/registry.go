@@ -0,0 +1,167 @@
+package rules
+
+import (
+  "fmt"
+  "sync"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+// Rule is a single check applied to file content.
+//
+// Third-party rules implement this interface (or use NewRule) and add
+// themselves with Register, typically from an init function.
+type Rule interface {
+  // Name identifies the rule in violations and on the command line
+  Name() string
+
+  // Description is a short human-readable summary of what the rule checks
+  Description() string
+
+  // Property is the EditorConfig property the rule implements
+  Property() string
+
+  // Fixable reports whether Fix can resolve the rule's violations
+  Fixable() bool
+
+  // Validate returns every violation of the rule in content
+  Validate(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError
+
+  // Fix returns content with the rule's violations resolved and whether
+  // anything changed. Rules that are not fixable return content unchanged.
+  Fix(filePath string, content []byte, cfg *config.ResolvedConfig) ([]byte, bool, error)
+}
+
+// funcRule adapts a ValidatorFunc and optional FixerFunc to the Rule interface
+type funcRule struct {
+  name        string
+  description string
+  property    string
+  validate    ValidatorFunc
+  fix         FixerFunc
+}
+
+// NewRule creates a Rule from a validator and an optional fixer. A nil fix
+// makes the rule validation-only.
+func NewRule(name, description, property string, validate ValidatorFunc, fix FixerFunc) Rule {
+  return &funcRule{
+    name:        name,
+    description: description,
+    property:    property,
+    validate:    validate,
+    fix:         fix,
+  }
+}
+
+func (r *funcRule) Name() string        { return r.name }
+func (r *funcRule) Description() string { return r.description }
+func (r *funcRule) Property() string    { return r.property }
+func (r *funcRule) Fixable() bool       { return r.fix != nil }
+
+func (r *funcRule) Validate(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError {
+  return r.validate(filePath, content, cfg)
+}
+
+func (r *funcRule) Fix(filePath string, content []byte, cfg *config.ResolvedConfig) ([]byte, bool, error) {
+  if r.fix == nil {
+    return content, false, nil
+  }
+  return r.fix(filePath, content, cfg)
+}
+
+// registry holds rules in registration order, which is also the order fixers run in
+type registry struct {
+  mu     sync.RWMutex
+  rules  []Rule
+  byName map[string]Rule
+}
+
+// defaultRegistry holds the built-in rules and any registered by other packages
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+  return &registry{byName: make(map[string]Rule)}
+}
+
+func (r *registry) register(rule Rule) {
+  r.mu.Lock()
+  defer r.mu.Unlock()
+
+  name := rule.Name()
+  if name == "" {
+    panic("rules: Register called with an empty rule name")
+  }
+  if _, exists := r.byName[name]; exists {
+    panic(fmt.Sprintf("rules: Register called twice for rule %s", name))
+  }
+
+  r.rules = append(r.rules, rule)
+  r.byName[name] = rule
+}
+
+func (r *registry) all() []Rule {
+  r.mu.RLock()
+  defer r.mu.RUnlock()
+
+  return append([]Rule(nil), r.rules...)
+}
+
+func (r *registry) lookup(name string) (Rule, bool) {
+  r.mu.RLock()
+  defer r.mu.RUnlock()
+
+  rule, ok := r.byName[name]
+  return rule, ok
+}
+
+func (r *registry) selectRules(enable, disable []string) ([]Rule, error) {
+  for _, name := range append(append([]string(nil), enable...), disable...) {
+    if _, ok := r.lookup(name); !ok {
+      return nil, fmt.Errorf("unknown rule %q", name)
+    }
+  }
+
+  enabled := make(map[string]bool, len(enable))
+  for _, name := range enable {
+    enabled[name] = true
+  }
+  disabled := make(map[string]bool, len(disable))
+  for _, name := range disable {
+    disabled[name] = true
+  }
+
+  var selected []Rule
+  for _, rule := range r.all() {
+    if len(enabled) > 0 && !enabled[rule.Name()] {
+      continue
+    }
+    if disabled[rule.Name()] {
+      continue
+    }
+    selected = append(selected, rule)
+  }
+
+  return selected, nil
+}
+
+// Register adds a rule to the registry. It panics if a rule with the same
+// name is already registered.
+func Register(rule Rule) {
+  defaultRegistry.register(rule)
+}
+
+// All returns every registered rule in registration order
+func All() []Rule {
+  return defaultRegistry.all()
+}
+
+// Lookup returns the registered rule with the given name
+func Lookup(name string) (Rule, bool) {
+  return defaultRegistry.lookup(name)
+}
+
+// Select returns the registered rules named in enable, or all rules if enable
+// is empty, minus those named in disable. Unknown names are an error.
+func Select(enable, disable []string) ([]Rule, error) {
+  return defaultRegistry.selectRules(enable, disable)
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+  "reflect"
+  "testing"
+
+  "github.com/dobbo-ca/editorlint/pkg/config"
+)
+
+func validateNothing(string, []byte, *config.ResolvedConfig) []ValidationError {
+  return nil
+}
+
+func TestBuiltinRules(t *testing.T) {
+  all := All()
+  if len(all) == 0 {
+    t.Fatal("Expected built-in rules to be registered")
+  }
+
+  // Charset must be fixed before the line-oriented rules
+  if all[0].Name() != "charset" {
+    t.Errorf("Expected charset to be the first rule, got %s", all[0].Name())
+  }
+
+  for _, rule := range all {
+    if rule.Description() == "" || rule.Property() == "" {
+      t.Errorf("Rule %s is missing metadata", rule.Name())
+    }
+
+    found, ok := Lookup(rule.Name())
+    if !ok || found != rule {
+      t.Errorf("Lookup(%q) did not return the registered rule", rule.Name())
+    }
+  }
+
+  if len(GetAllFixers()) >= len(GetAllValidators()) {
+    t.Error("Expected validation-only rules to be excluded from fixers")
+  }
+}
+
+func TestRegistryRegister(t *testing.T) {
+  r := newRegistry()
+  r.register(NewRule("custom", "Custom rule", "custom_property", validateNothing, nil))
+
+  rule, ok := r.lookup("custom")
+  if !ok {
+    t.Fatal("Expected registered rule to be found")
+  }
+
+  if rule.Fixable() {
+    t.Error("Expected rule without fixer to not be fixable")
+  }
+
+  content := []byte("unchanged")
+  fixed, changed, err := rule.Fix("test.txt", content, &config.ResolvedConfig{})
+  if err != nil || changed || string(fixed) != "unchanged" {
+    t.Errorf("Expected Fix to be a no-op, got %q, %v, %v", fixed, changed, err)
+  }
+
+  defer func() {
+    if recover() == nil {
+      t.Error("Expected duplicate registration to panic")
+    }
+  }()
+  r.register(NewRule("custom", "Duplicate", "custom_property", validateNothing, nil))
+}
+
+func TestRegistrySelect(t *testing.T) {
+  r := newRegistry()
+  for _, name := range []string{"a", "b", "c"} {
+    r.register(NewRule(name, "Rule "+name, name, validateNothing, nil))
+  }
+
+  tests := []struct {
+    name    string
+    enable  []string
+    disable []string
+    want    []string
+    wantErr bool
+  }{
+    {name: "all by default", want: []string{"a", "b", "c"}},
+    {name: "enable keeps registration order", enable: []string{"c", "a"}, want: []string{"a", "c"}},
+    {name: "disable", disable: []string{"b"}, want: []string{"a", "c"}},
+    {name: "disable overrides enable", enable: []string{"a", "b"}, disable: []string{"a"}, want: []string{"b"}},
+    {name: "disable everything", disable: []string{"a", "b", "c"}, want: nil},
+    {name: "unknown enabled rule", enable: []string{"x"}, wantErr: true},
+    {name: "unknown disabled rule", disable: []string{"x"}, wantErr: true},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      selected, err := r.selectRules(tt.enable, tt.disable)
+      if tt.wantErr {
+        if err == nil {
+          t.Error("Expected error, but got none")
+        }
+        return
+      }
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      var names []string
+      for _, rule := range selected {
+        names = append(names, rule.Name())
+      }
+
+      if !reflect.DeepEqual(names, tt.want) {
+        t.Errorf("Expected %v, got %v", tt.want, names)
+      }
+    })
+  }
+}
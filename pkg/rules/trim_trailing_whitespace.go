@@ -8,37 +8,39 @@ import (
 )
 
 // ValidateTrimTrailingWhitespace checks if the file has trailing whitespace when it shouldn't
-func ValidateTrimTrailingWhitespace(filePath string, content []byte, cfg *config.ResolvedConfig) *ValidationError {
+func ValidateTrimTrailingWhitespace(filePath string, content []byte, cfg *config.ResolvedConfig) []ValidationError {
   // Only validate if trim_trailing_whitespace is explicitly set to true
   if cfg.TrimTrailingWhitespace == nil || !*cfg.TrimTrailingWhitespace {
     return nil
   }
 
+  // UTF-16 content is checked as decoded text
+  if !asciiCompatible(cfg) {
+    return validateDecoded(filePath, content, cfg, ValidateTrimTrailingWhitespace)
+  }
+
   if len(content) == 0 {
     return nil // Empty files are fine
   }
 
-  lines := bytes.Split(content, []byte("\n"))
+  index := newLineIndex(content)
 
   // Check each line for trailing whitespace
-  for i, line := range lines {
-    // Skip the last line if it's empty (this would be after the final newline)
-    if i == len(lines)-1 && len(line) == 0 {
+  var errors []ValidationError
+  for _, line := range splitLines(content) {
+    trimmed := bytes.TrimRight(line.text, " \t")
+    if len(trimmed) == len(line.text) {
       continue
     }
 
-    // Check if line has trailing whitespace
-    if len(line) > 0 && isWhitespace(line[len(line)-1]) {
-      lineNum := i + 1
-      return &ValidationError{
-        FilePath: filePath,
-        Rule:     "trim_trailing_whitespace",
-        Message:  fmt.Sprintf("line %d has trailing whitespace", lineNum),
-      }
-    }
+    offset, length := line.offset+len(trimmed), len(line.text)-len(trimmed)
+    err := index.violation(filePath, "trim_trailing_whitespace", offset, length,
+      fmt.Sprintf("line %d has trailing whitespace", line.number))
+    err.Edits = []TextEdit{index.edit(offset, length, "")}
+    errors = append(errors, err)
   }
 
-  return nil
+  return errors
 }
 
 // FixTrimTrailingWhitespace removes trailing whitespace from all lines
@@ -48,62 +50,33 @@ func FixTrimTrailingWhitespace(filePath string, content []byte, cfg *config.Reso
     return content, false, nil
   }
 
-  if len(content) == 0 {
-    return content, false, nil // Empty files are fine
+  // UTF-16 content is fixed as decoded text
+  if !asciiCompatible(cfg) {
+    return fixDecoded(filePath, content, cfg, FixTrimTrailingWhitespace)
   }
 
-  // Determine line ending to preserve
-  var lineEnding []byte
-  if bytes.Contains(content, []byte("\r\n")) {
-    lineEnding = []byte("\r\n")
-  } else if bytes.Contains(content, []byte("\r")) {
-    lineEnding = []byte("\r")
-  } else {
-    lineEnding = []byte("\n")
+  if len(content) == 0 {
+    return content, false, nil // Empty files are fine
   }
 
-  lines := bytes.Split(content, []byte("\n"))
+  // Rebuild the content line by line, keeping each line's original ending
+  fixed := make([]byte, 0, len(content))
   hasChanges := false
 
-  for i, line := range lines {
-    // Skip the last line if it's empty (this would be after the final newline)
-    if i == len(lines)-1 && len(line) == 0 {
-      continue
-    }
-
-    // Remove trailing whitespace
-    trimmed := bytes.TrimRightFunc(line, func(r rune) bool {
-      return r == ' ' || r == '\t'
-    })
-
-    if !bytes.Equal(line, trimmed) {
-      lines[i] = trimmed
+  for _, line := range splitLines(content) {
+    trimmed := bytes.TrimRight(line.text, " \t")
+    if len(trimmed) != len(line.text) {
       hasChanges = true
     }
+    fixed = append(fixed, trimmed...)
+    fixed = append(fixed, line.ending...)
   }
 
   if !hasChanges {
     return content, false, nil
   }
 
-  // Rejoin lines with original line ending
-  var result bytes.Buffer
-  for i, line := range lines {
-    result.Write(line)
-    // Add line ending except for the last empty line
-    if i < len(lines)-1 {
-      if i == len(lines)-2 && len(lines[len(lines)-1]) == 0 {
-        // This is the second to last line and the last line is empty
-        // So we're before the final newline
-        result.Write(lineEnding)
-      } else if i < len(lines)-2 || len(lines[len(lines)-1]) > 0 {
-        // Not the last line, or the last line is not empty
-        result.Write(lineEnding)
-      }
-    }
-  }
-
-  return result.Bytes(), true, nil
+  return fixed, true, nil
 }
 
 // isWhitespace checks if a byte is whitespace (space or tab)
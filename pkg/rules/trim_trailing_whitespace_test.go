@@ -45,19 +45,35 @@ func TestValidateTrimTrailingWhitespace(t *testing.T) {
         TrimTrailingWhitespace: &tt.trimTrailingWhitespace,
       }
 
-      err := ValidateTrimTrailingWhitespace("test.go", []byte(tt.content), cfg)
+      errs := ValidateTrimTrailingWhitespace("test.go", []byte(tt.content), cfg)
 
-      if tt.wantError && err == nil {
+      if tt.wantError && len(errs) == 0 {
         t.Error("Expected validation error, but got none")
       }
 
-      if !tt.wantError && err != nil {
-        t.Errorf("Expected no validation error, but got: %v", err)
+      if !tt.wantError && len(errs) > 0 {
+        t.Errorf("Expected no validation error, but got: %v", errs)
       }
     })
   }
 }
 
+func TestValidateTrimTrailingWhitespaceReportsEveryLine(t *testing.T) {
+  trim := true
+  cfg := &config.ResolvedConfig{TrimTrailingWhitespace: &trim}
+
+  errs := ValidateTrimTrailingWhitespace("test.go", []byte("a \nb\nc\t\nd  \n"), cfg)
+  if len(errs) != 3 {
+    t.Fatalf("Expected 3 validation errors, got %d: %v", len(errs), errs)
+  }
+
+  for i, line := range []int{1, 3, 4} {
+    if errs[i].Line != line {
+      t.Errorf("Expected error %d on line %d, got %d", i, line, errs[i].Line)
+    }
+  }
+}
+
 func TestFixTrimTrailingWhitespace(t *testing.T) {
   tests := []struct {
     name                   string
@@ -80,6 +96,20 @@ func TestFixTrimTrailingWhitespace(t *testing.T) {
       expectedContent:        "package main\nfunc main() {\n}\n",
       expectFixed:            true,
     },
+    {
+      name:                   "preserves CRLF line endings",
+      content:                "package main \r\nfunc main() {\r\n}\r\n",
+      trimTrailingWhitespace: true,
+      expectedContent:        "package main\r\nfunc main() {\r\n}\r\n",
+      expectFixed:            true,
+    },
+    {
+      name:                   "preserves CR line endings",
+      content:                "a \r\tb \r",
+      trimTrailingWhitespace: true,
+      expectedContent:        "a\r\tb\r",
+      expectFixed:            true,
+    },
     {
       name:                   "no trailing whitespace",
       content:                "package main\nfunc main() {\n}\n",
@@ -12,18 +12,49 @@ import (
 )
 
 // ValidationError represents a validation failure
+//
+// Positions are 1-based and columns count bytes from the start of the line.
+// EndLine and EndColumn mark the position just after the violation, so a
+// zero-length violation (such as a missing final newline) starts and ends at
+// the same place. A Line of 0 means the error has no position in the file.
+//
+// Edits holds the changes the rule's fixer would make to resolve this
+// violation, or is empty if the violation cannot be fixed automatically.
 type ValidationError struct {
-  FilePath string
-  Rule     string
-  Message  string
+  FilePath   string
+  Rule       string
+  Message    string
+  Line       int
+  Column     int
+  EndLine    int
+  EndColumn  int
+  Offset     int    // 0-based byte offset of the start of the violation
+  Length     int    // Length of the violation in bytes
+  LineText   string // Text of the line the violation starts on, without its line ending
+  LineEnding string // Line ending of that line (LF, CRLF or CR), empty for the last line
+  Edits      []TextEdit
+}
+
+// TextEdit replaces Length bytes at Offset with NewText. A zero Length is an
+// insertion and an empty NewText is a deletion. Positions follow the same
+// conventions as ValidationError.
+type TextEdit struct {
+  Line      int
+  Column    int
+  EndLine   int
+  EndColumn int
+  Offset    int
+  Length    int
+  NewText   string
 }
 
 func (e ValidationError) Error() string {
   return fmt.Sprintf("%s: %s violation - %s", e.FilePath, e.Rule, e.Message)
 }
 
-// ValidatorFunc is a function that validates a file against a specific rule
-type ValidatorFunc func(string, []byte, *config.ResolvedConfig) *ValidationError
+// ValidatorFunc is a function that validates a file against a specific rule.
+// It returns every violation found, or nil if the file passes.
+type ValidatorFunc func(string, []byte, *config.ResolvedConfig) []ValidationError
 
 // FixerFunc is a function that fixes violations of a specific rule
 type FixerFunc func(string, []byte, *config.ResolvedConfig) ([]byte, bool, error)
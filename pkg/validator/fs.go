@@ -0,0 +1,36 @@
+package validator
+
+import (
+  "io/fs"
+  "os"
+)
+
+// WritableFS is a file system that fixed files can be written back to.
+// Fix mode requires Config.FS to implement it.
+type WritableFS interface {
+  fs.FS
+
+  // WriteFile replaces the contents of the named file
+  WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// osFS is the file system used when Config.FS is nil. Unlike os.DirFS it
+// accepts operating system paths, relative or absolute, so results name
+// files exactly as they were given.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+  return os.Open(name)
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+  return os.Stat(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+  return os.ReadFile(name)
+}
+
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+  return os.WriteFile(name, data, perm)
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+  "github.com/dobbo-ca/editorlint/pkg/config"
+  "github.com/dobbo-ca/editorlint/pkg/output"
+  "github.com/dobbo-ca/editorlint/pkg/rules"
+)
+
+// Report is the outcome of a Check
+type Report struct {
+  // Mode is "validate", or "fix" when files were fixed
+  Mode        string
+
+  // Files holds the result for every processed file, in discovery order
+  Files       []FileResult
+
+  // Rules are the rules that were applied
+  Rules       []rules.Rule
+
+  // Interrupted reports that the Check was cancelled before every file was
+  // processed, so Files is incomplete
+  Interrupted bool
+}
+
+// FileResult is the outcome of checking one file
+type FileResult struct {
+  Path       string
+
+  // Config is the EditorConfig resolved for the file, or nil if it could
+  // not be resolved
+  Config     *config.ResolvedConfig
+
+  // Errors are the violations found in validate mode. They are nil once
+  // passed to OnFile when DiscardErrors is set.
+  Errors     []rules.ValidationError
+
+  // Violations is the number of violations found, kept when Errors are
+  // discarded
+  Violations int
+
+  // Fixed reports whether the file was modified in fix mode
+  Fixed      bool
+
+  // Err is why the file could not be read, resolved or fixed, or nil
+  Err        error
+}
+
+// Violations returns the number of violations found in all files, including
+// any that were discarded
+func (r *Report) Violations() int {
+  count := 0
+  for _, file := range r.Files {
+    count += file.Violations
+  }
+  return count
+}
+
+// Errors returns the violations kept for all files
+func (r *Report) Errors() []rules.ValidationError {
+  var errors []rules.ValidationError
+  for _, file := range r.Files {
+    errors = append(errors, file.Errors...)
+  }
+  return errors
+}
+
+// FixedFiles returns the paths of the files that were modified
+func (r *Report) FixedFiles() []string {
+  var fixed []string
+  for _, file := range r.Files {
+    if file.Fixed {
+      fixed = append(fixed, file.Path)
+    }
+  }
+  return fixed
+}
+
+// FileErrors returns the results of the files that could not be processed
+func (r *Report) FileErrors() []FileResult {
+  var failed []FileResult
+  for _, file := range r.Files {
+    if file.Err != nil {
+      failed = append(failed, file)
+    }
+  }
+  return failed
+}
+
+// Success reports whether every file was processed and no violations were
+// found, or in fix mode whether no files needed fixing
+func (r *Report) Success() bool {
+  if len(r.FileErrors()) > 0 {
+    return false
+  }
+  if r.Mode == "fix" {
+    return len(r.FixedFiles()) == 0
+  }
+  return r.Violations() == 0
+}
+
+// Result converts the report for formatting by package output
+func (r *Report) Result() *output.Result {
+  result := &output.Result{
+    Errors:      r.Errors(),
+    FixedFiles:  r.FixedFiles(),
+    TotalFiles:  len(r.Files),
+    Success:     r.Success(),
+    Mode:        r.Mode,
+    Rules:       r.Rules,
+    Interrupted: r.Interrupted,
+  }
+  result.Violations = r.Violations()
+  for _, file := range r.Files {
+    result.Files = append(result.Files, file.Path)
+    if file.Err != nil {
+      result.FileErrors = append(result.FileErrors, output.FileError{
+        FilePath: file.Path,
+        Message:  file.Err.Error(),
+      })
+    }
+  }
+  return result
+}
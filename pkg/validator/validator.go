@@ -7,20 +7,21 @@
 // - Handling both single files and directory trees
 //
 // The Validator type is the main entry point that coordinates between the config
-// resolution and rules application.
+// resolution and rules application. It returns its findings as a Report and
+// prints nothing; package output formats them.
 package validator
 
 import (
+  "context"
   "fmt"
-  "os"
+  "io/fs"
+  "path"
   "path/filepath"
-  "regexp"
   "runtime"
   "strings"
   "sync"
 
   "github.com/dobbo-ca/editorlint/pkg/config"
-  "github.com/dobbo-ca/editorlint/pkg/output"
   "github.com/dobbo-ca/editorlint/pkg/rules"
 )
 
@@ -38,26 +39,47 @@ type Config struct {
   // of just reporting them.
   Fix              bool
 
-  // OutputFormat specifies how to format output: default, tabular, json, quiet
-  OutputFormat     string
-
   // Workers specifies the number of parallel workers for file processing.
   // If 0, uses runtime.NumCPU().
   Workers          int
 
-  // Quiet enables minimal output mode
-  Quiet            bool
-
   // ExcludePatterns specifies glob patterns for files/directories to exclude
   ExcludePatterns  []string
+
+  // EnableRules names the rules to run. If empty, all registered rules run.
+  EnableRules      []string
+
+  // DisableRules names rules to skip, applied after EnableRules
+  DisableRules     []string
+
+  // FS is the file system that targets, EditorConfig files and
+  // CustomConfigPath are read from, using slash-separated fs.FS paths. Fix
+  // mode requires it to implement WritableFS. If nil, the operating system's
+  // file system is used with ordinary paths.
+  FS               fs.FS
+
+  // OnFile, if set, is called with each file's result as soon as the file
+  // has been processed. Calls are made from a single goroutine.
+  OnFile           func(FileResult)
+
+  // DiscardErrors drops each file's violations from the Report once OnFile
+  // has received them, keeping only their count, so that memory does not
+  // grow with the number of violations. It has no effect without OnFile.
+  DiscardErrors    bool
+
+  // FailFast stops the Check at the first file that cannot be processed.
+  // Otherwise the remaining files are still processed and the failure is
+  // only recorded in the file's FileResult.
+  FailFast         bool
 }
 
 // Validator handles file validation and fixing according to EditorConfig rules.
 // It coordinates between configuration resolution and rule application.
 type Validator struct {
-  config    Config
-  formatter *output.Formatter
-  workers   int
+  config  Config
+  fsys    fs.FS
+  workers int
+  rules   []rules.Rule
 }
 
 // New creates a new validator with the given configuration.
@@ -68,276 +90,220 @@ func New(cfg Config) *Validator {
     workers = runtime.NumCPU()
   }
 
-  formatter := output.NewFormatter(cfg.OutputFormat, cfg.Quiet)
+  fsys := cfg.FS
+  if fsys == nil {
+    fsys = osFS{}
+  }
 
   return &Validator{
-    config:    cfg,
-    formatter: formatter,
-    workers:   workers,
+    config:  cfg,
+    fsys:    fsys,
+    workers: workers,
   }
 }
 
-// ValidateTarget validates a target file or directory according to EditorConfig rules.
+// Check validates each target file or directory according to EditorConfig
+// rules, or fixes them when Fix is true, and returns the outcome per file.
 //
-// If target is a file, validates that single file. If target is a directory,
-// validates all eligible files in the directory (recursively if Recursive is true).
-// When Fix is true, automatically fixes validation errors instead of reporting them.
+// Directory targets cover all eligible files in the directory (recursively
+// if Recursive is true). Violations are not errors: they are recorded in the
+// Report. Neither are files that cannot be read, resolved or fixed: their
+// FileResult holds the error instead. An error is returned if the rules, a
+// target or a directory's EditorConfig cannot be resolved.
 //
-// Returns an error if validation fails or if any validation errors are found
-// (in non-fix mode).
-func (v *Validator) ValidateTarget(target string) error {
-  // Check if target is a file or directory
-  info, err := os.Stat(target)
+// If ctx is cancelled or its deadline passes, file discovery stops and no
+// further files are started. Files already being processed are finished, so
+// fixes are never left half written, and the Report holds them with
+// Interrupted set. The error is then ctx.Err(). With FailFast the same
+// happens at the first file that cannot be processed, and the error is that
+// file's.
+func (v *Validator) Check(ctx context.Context, targets []string) (*Report, error) {
+  v, err := v.withRules()
   if err != nil {
-    return fmt.Errorf("cannot access target: %w", err)
+    return nil, err
   }
 
-  if info.IsDir() {
-    return v.validateDirectory(target)
-  } else {
-    return v.validateSingleFile(target)
+  report := &Report{Mode: "validate", Rules: v.rules}
+  if v.config.Fix {
+    report.Mode = "fix"
   }
-}
 
-func (v *Validator) validateDirectory(directory string) error {
-  // Check if .editorconfig exists (unless using custom config)
-  if v.config.CustomConfigPath == "" {
-    if err := v.checkForEditorConfig(directory); err != nil {
-      return err
-    }
-  }
+  // Failing fast cancels the files still to be processed
+  runCtx, cancel := context.WithCancel(ctx)
+  defer cancel()
 
-  // Print progress unless in quiet mode
-  if !v.config.Quiet {
-    mode := "Validating"
-    if v.config.Fix {
-      mode = "Fixing"
+  var failure error
+  onFile := func(result *FileResult) {
+    if result.Err != nil && failure == nil {
+      failure = result.Err
+      if v.config.FailFast {
+        cancel()
+      }
+    }
+    if v.config.OnFile != nil {
+      v.config.OnFile(*result)
+      if v.config.DiscardErrors {
+        result.Errors = nil
+      }
     }
-    fmt.Printf("%s directory: %s (recursive: %v)\n", mode, directory, v.config.Recursive)
   }
 
-  if v.config.Fix {
-    // Fix mode: fix all validation errors
-    fixed, totalFiles, err := v.fixFilesParallel(directory)
-    if err != nil {
-      return err
+  // stopped explains why runCtx was cancelled
+  stopped := func() error {
+    if v.config.FailFast && failure != nil {
+      return failure
     }
+    return ctx.Err()
+  }
 
-    result := &output.Result{
-      FixedFiles: fixed,
-      TotalFiles: totalFiles,
-      Success:    len(fixed) == 0, // Success if no fixes were needed
-      Mode:       "fix",
+  for _, target := range targets {
+    if runCtx.Err() != nil {
+      report.Interrupted = true
+      return report, stopped()
     }
 
-    v.formatter.FormatResults(result)
-    return nil
-  } else {
-    // Validate mode: report validation errors
-    errors, totalFiles, err := v.validateFilesParallel(directory)
+    // Check if target is a file or directory
+    info, err := fs.Stat(v.fsys, target)
     if err != nil {
-      return err
+      return report, fmt.Errorf("cannot access target: %w", err)
     }
 
-    result := &output.Result{
-      Errors:     errors,
-      TotalFiles: totalFiles,
-      Success:    len(errors) == 0,
-      Mode:       "validate",
+    var files []FileResult
+    if info.IsDir() {
+      files, err = v.checkDirectory(runCtx, target, onFile)
+    } else {
+      files = v.checkSingleFile(target, onFile)
     }
-
-    v.formatter.FormatResults(result)
-
-    if len(errors) > 0 {
-      return fmt.Errorf("validation failed with %d errors", len(errors))
+    report.Files = append(report.Files, files...)
+    if err != nil {
+      if runCtx.Err() != nil {
+        report.Interrupted = true
+        return report, stopped()
+      }
+      return report, err
     }
+  }
 
-    return nil
+  if v.config.FailFast && failure != nil {
+    return report, failure
   }
+  return report, nil
 }
 
-func (v *Validator) validateSingleFile(filePath string) error {
-  // Print progress unless in quiet mode
-  if !v.config.Quiet {
-    mode := "Validating"
-    if v.config.Fix {
-      mode = "Fixing"
-    }
-    fmt.Printf("%s file: %s\n", mode, filePath)
+// ValidateContent validates content as if it were the file at virtualPath.
+// EditorConfig rules are resolved for virtualPath, but the content is never
+// read from or written to the filesystem.
+func (v *Validator) ValidateContent(virtualPath string, content []byte) ([]rules.ValidationError, error) {
+  v, err := v.withRules()
+  if err != nil {
+    return nil, err
   }
 
-  if v.config.Fix {
-    // Fix mode: fix validation errors in single file
-    fixed, err := v.fixSingleFile(filePath)
-    if err != nil {
-      return err
-    }
-
-    var fixedFiles []string
-    if fixed {
-      fixedFiles = []string{filePath}
-    }
-
-    result := &output.Result{
-      FixedFiles: fixedFiles,
-      TotalFiles: 1,
-      Success:    !fixed, // Success if no fixes were needed
-      Mode:       "fix",
-    }
+  resolvedConfig, err := v.resolveConfig(virtualPath)
+  if err != nil {
+    return nil, err
+  }
 
-    v.formatter.FormatResults(result)
-    return nil
-  } else {
-    // Validate mode: report validation errors
-    errors, err := v.validateSingleFileErrors(filePath)
-    if err != nil {
-      return err
-    }
+  return v.validateContent(virtualPath, content, resolvedConfig), nil
+}
 
-    result := &output.Result{
-      Errors:     errors,
-      TotalFiles: 1,
-      Success:    len(errors) == 0,
-      Mode:       "validate",
-    }
+// FixContent fixes content as if it were the file at virtualPath, returning
+// the fixed content and whether it changed. Like ValidateContent, it does not
+// touch the filesystem for the content itself.
+func (v *Validator) FixContent(virtualPath string, content []byte) ([]byte, bool, error) {
+  v, err := v.withRules()
+  if err != nil {
+    return nil, false, err
+  }
 
-    v.formatter.FormatResults(result)
+  resolvedConfig, err := v.resolveConfig(virtualPath)
+  if err != nil {
+    return nil, false, err
+  }
 
-    if len(errors) > 0 {
-      return fmt.Errorf("validation failed with %d errors", len(errors))
-    }
+  return v.fixContent(virtualPath, content, resolvedConfig)
+}
 
-    return nil
+// withRules returns a copy of the validator with the rules to apply
+// resolved, so concurrent calls never modify the shared Validator
+func (v *Validator) withRules() (*Validator, error) {
+  selected, err := rules.Select(v.config.EnableRules, v.config.DisableRules)
+  if err != nil {
+    return nil, err
   }
+  run := *v
+  run.rules = selected
+  return &run, nil
 }
 
-// validateFiles validates all files in the given directory against editorconfig rules
-func (v *Validator) validateFiles(directory string) ([]rules.ValidationError, error) {
-  var errors []rules.ValidationError
-
-  walkErr := filepath.Walk(directory, func(path string, info os.FileInfo, walkErr error) error {
-    if walkErr != nil {
-      return walkErr
-    }
-
-    // Skip directories
-    if info.IsDir() {
-      // Check if directory should be ignored
-      if v.shouldIgnore(path) {
-        return filepath.SkipDir
-      }
-      // If not recursive, skip subdirectories
-      if !v.config.Recursive && path != directory {
-        return filepath.SkipDir
-      }
-      return nil
-    }
-
-    // Skip .editorconfig files themselves
-    if info.Name() == ".editorconfig" {
-      return nil
-    }
-
-    // Skip hidden files and directories (optional - could be configurable)
-    if strings.HasPrefix(info.Name(), ".") {
-      return nil
-    }
-
-    // Check if file should be ignored
-    if v.shouldIgnore(path) {
-      return nil
-    }
-
-    // Skip binary files and executable files
-    if isBinaryFile(path, info) {
-      return nil
-    }
-
-    // Convert to absolute path for config resolution
-    absPath, err := filepath.Abs(path)
-    if err != nil {
-      return fmt.Errorf("failed to get absolute path for %s: %w", path, err)
-    }
-
-    // Find applicable editorconfig files for this file
-    var configs []*config.EditorConfig
-    var configErr error
-
-    if v.config.CustomConfigPath != "" {
-      configs, configErr = config.FindEditorConfigsWithCustomConfig(absPath, v.config.CustomConfigPath)
-    } else {
-      configs, configErr = config.FindEditorConfigs(absPath)
-    }
-
-    if configErr != nil {
-      return fmt.Errorf("failed to find editorconfig for %s: %w", path, configErr)
-    }
-
-    if len(configs) == 0 {
-      // No .editorconfig found
-      return fmt.Errorf(".editorconfig file not found in directory hierarchy for %s", path)
-    }
-
-    // Resolve configuration for this specific file
-    resolvedConfig, err := config.ResolveConfigForFile(absPath, configs)
-    if err != nil {
-      return fmt.Errorf("failed to resolve config for %s: %w", path, err)
+func (v *Validator) checkDirectory(ctx context.Context, directory string, onFile func(*FileResult)) ([]FileResult, error) {
+  // Check if .editorconfig exists (unless using custom config)
+  if v.config.CustomConfigPath == "" {
+    if err := v.checkForEditorConfig(directory); err != nil {
+      return nil, err
     }
+  }
 
-    // Validate the file against the resolved configuration (use original path for error messages)
-    fileErrors := v.validateFile(path, resolvedConfig)
-    errors = append(errors, fileErrors...)
+  // Collect all files to process
+  files, err := v.collectFiles(ctx, directory)
+  if err != nil {
+    return nil, err
+  }
 
-    return nil
-  })
+  return v.checkFilesParallel(ctx, files, onFile)
+}
 
-  return errors, walkErr
+func (v *Validator) checkSingleFile(filePath string, onFile func(*FileResult)) []FileResult {
+  result := v.checkFile(filePath)
+  onFile(&result)
+  return []FileResult{result}
 }
 
-// validateFile validates a single file against its resolved editorconfig
-func (v *Validator) validateFile(filePath string, cfg *config.ResolvedConfig) []rules.ValidationError {
-  var errors []rules.ValidationError
+// checkFile resolves the configuration for a file, then validates or fixes
+// it. Failures are recorded in the result's Err.
+func (v *Validator) checkFile(filePath string) FileResult {
+  result := FileResult{Path: filePath}
 
-  // Read the file
-  content, err := os.ReadFile(filePath)
+  resolvedConfig, err := v.resolveConfig(filePath)
   if err != nil {
-    errors = append(errors, rules.ValidationError{
-      FilePath: filePath,
-      Rule:     "file_access",
-      Message:  fmt.Sprintf("could not read file: %v", err),
-    })
-    return errors
+    result.Err = err
+    return result
   }
+  result.Config = resolvedConfig
 
-  // Run all validation checks
-  validators := rules.GetAllValidators()
-
-  for _, validator := range validators {
-    if err := validator(filePath, content, cfg); err != nil {
-      errors = append(errors, *err)
-    }
+  if v.config.Fix {
+    result.Fixed, result.Err = v.fixFile(filePath, resolvedConfig)
+  } else {
+    result.Errors, result.Err = v.validateFile(filePath, resolvedConfig)
+    result.Violations = len(result.Errors)
   }
-
-  return errors
+  return result
 }
 
-// Additional methods for fixing, single file validation, etc.
-func (v *Validator) validateSingleFileErrors(filePath string) ([]rules.ValidationError, error) {
+// resolveConfig resolves the EditorConfig properties that apply to a file
+func (v *Validator) resolveConfig(filePath string) (*config.ResolvedConfig, error) {
   // Convert to absolute path for config resolution
-  absPath, err := filepath.Abs(filePath)
+  absPath, err := v.lookupPath(filePath)
   if err != nil {
     return nil, fmt.Errorf("failed to get absolute path for %s: %w", filePath, err)
   }
 
-  // Find applicable editorconfig files for this file
-  var configs []*config.EditorConfig
+  customConfigPath := v.config.CustomConfigPath
+  if customConfigPath != "" {
+    if customConfigPath, err = v.lookupPath(customConfigPath); err != nil {
+      return nil, fmt.Errorf("failed to get absolute path for config file: %w", err)
+    }
+  }
 
-  if v.config.CustomConfigPath != "" {
-    configs, err = config.FindEditorConfigsWithCustomConfig(absPath, v.config.CustomConfigPath)
+  // Find applicable editorconfig files for this file. On the operating
+  // system's file system the search uses native paths, so it stops at the
+  // volume root on Windows.
+  var configs []*config.EditorConfig
+  if _, ok := v.fsys.(osFS); ok {
+    configs, err = config.FindEditorConfigsWithCustomConfig(absPath, customConfigPath)
   } else {
-    configs, err = config.FindEditorConfigs(absPath)
+    configs, err = config.FindEditorConfigsFS(v.fsys, absPath, customConfigPath)
   }
-
   if err != nil {
     return nil, fmt.Errorf("failed to find editorconfig for %s: %w", filePath, err)
   }
@@ -352,65 +318,79 @@ func (v *Validator) validateSingleFileErrors(filePath string) ([]rules.Validatio
     return nil, fmt.Errorf("failed to resolve config for %s: %w", filePath, err)
   }
 
-  // Validate the file against the resolved configuration
-  errors := v.validateFile(filePath, resolvedConfig)
-  return errors, nil
+  return resolvedConfig, nil
 }
 
-func (v *Validator) fixSingleFile(filePath string) (bool, error) {
-  // Convert to absolute path for config resolution
-  absPath, err := filepath.Abs(filePath)
-  if err != nil {
-    return false, fmt.Errorf("failed to get absolute path for %s: %w", filePath, err)
+// lookupPath returns the path used to look up EditorConfig files. On the
+// operating system's file system the search continues above the target, so
+// the path is made absolute; other file systems are searched up to their root.
+func (v *Validator) lookupPath(name string) (string, error) {
+  if _, ok := v.fsys.(osFS); !ok {
+    return name, nil
   }
+  return filepath.Abs(name)
+}
 
-  // Find applicable editorconfig files for this file
-  var configs []*config.EditorConfig
+// parentDir returns the directory containing name, as path.Dir does for
+// fs.FS names. Native paths are used on the operating system's file system,
+// where the walk must end at a Windows volume root rather than ".".
+func (v *Validator) parentDir(name string) string {
+  if _, ok := v.fsys.(osFS); ok {
+    return filepath.Dir(name)
+  }
+  return path.Dir(name)
+}
 
-  if v.config.CustomConfigPath != "" {
-    configs, err = config.FindEditorConfigsWithCustomConfig(absPath, v.config.CustomConfigPath)
-  } else {
-    configs, err = config.FindEditorConfigs(absPath)
+// joinPath joins path elements with the separator of the file system in use
+func (v *Validator) joinPath(elem ...string) string {
+  if _, ok := v.fsys.(osFS); ok {
+    return filepath.Join(elem...)
   }
+  return path.Join(elem...)
+}
 
+// validateFile validates a single file against its resolved editorconfig
+func (v *Validator) validateFile(filePath string, cfg *config.ResolvedConfig) ([]rules.ValidationError, error) {
+  // Read the file
+  content, err := fs.ReadFile(v.fsys, filePath)
   if err != nil {
-    return false, fmt.Errorf("failed to find editorconfig for %s: %w", filePath, err)
+    return nil, fmt.Errorf("could not read file %s: %w", filePath, err)
   }
 
-  if len(configs) == 0 {
-    return false, fmt.Errorf(".editorconfig file not found in directory hierarchy for %s", filePath)
+  return v.validateContent(filePath, content, cfg), nil
+}
+
+// validateContent runs all selected validation checks on a file's content
+func (v *Validator) validateContent(filePath string, content []byte, cfg *config.ResolvedConfig) []rules.ValidationError {
+  var errors []rules.ValidationError
+  for _, rule := range v.rules {
+    errors = append(errors, rule.Validate(filePath, content, cfg)...)
   }
+  return errors
+}
 
-  // Resolve configuration for this specific file
-  resolvedConfig, err := config.ResolveConfigForFile(absPath, configs)
-  if err != nil {
-    return false, fmt.Errorf("failed to resolve config for %s: %w", filePath, err)
+// fixFile applies the fixers of all selected rules to a file and reports
+// whether it was modified
+func (v *Validator) fixFile(filePath string, cfg *config.ResolvedConfig) (bool, error) {
+  writable, ok := v.fsys.(WritableFS)
+  if !ok {
+    return false, fmt.Errorf("cannot fix %s: file system is read-only", filePath)
   }
 
   // Read the file
-  content, err := os.ReadFile(filePath)
+  content, err := fs.ReadFile(v.fsys, filePath)
   if err != nil {
     return false, fmt.Errorf("could not read file %s: %w", filePath, err)
   }
 
-  // Apply all fixers
-  fixers := rules.GetAllFixers()
-  modified := false
-
-  for _, fixer := range fixers {
-    newContent, changed, err := fixer(filePath, content, resolvedConfig)
-    if err != nil {
-      return false, fmt.Errorf("failed to apply fixer to %s: %w", filePath, err)
-    }
-    if changed {
-      content = newContent
-      modified = true
-    }
+  content, modified, err := v.fixContent(filePath, content, cfg)
+  if err != nil {
+    return false, err
   }
 
   // Write back to file if modified
   if modified {
-    err = os.WriteFile(filePath, content, 0644)
+    err = writable.WriteFile(filePath, content, 0644)
     if err != nil {
       return false, fmt.Errorf("failed to write fixed file %s: %w", filePath, err)
     }
@@ -419,87 +399,52 @@ func (v *Validator) fixSingleFile(filePath string) (bool, error) {
   return modified, nil
 }
 
-func (v *Validator) fixFiles(directory string) ([]string, error) {
-  var fixedFiles []string
-
-  walkErr := filepath.Walk(directory, func(path string, info os.FileInfo, walkErr error) error {
-    if walkErr != nil {
-      return walkErr
-    }
-
-    // Skip directories
-    if info.IsDir() {
-      // Check if directory should be ignored
-      if v.shouldIgnore(path) {
-        return filepath.SkipDir
-      }
-      // If not recursive, skip subdirectories
-      if !v.config.Recursive && path != directory {
-        return filepath.SkipDir
-      }
-      return nil
-    }
-
-    // Skip .editorconfig files themselves
-    if info.Name() == ".editorconfig" {
-      return nil
-    }
-
-    // Skip hidden files and directories (optional - could be configurable)
-    if strings.HasPrefix(info.Name(), ".") {
-      return nil
-    }
-
-    // Check if file should be ignored
-    if v.shouldIgnore(path) {
-      return nil
-    }
+// fixContent applies the fixers of all selected rules to a file's content
+func (v *Validator) fixContent(filePath string, content []byte, cfg *config.ResolvedConfig) ([]byte, bool, error) {
+  modified := false
 
-    // Skip binary files and executable files
-    if isBinaryFile(path, info) {
-      return nil
+  for _, rule := range v.rules {
+    if !rule.Fixable() {
+      continue
     }
 
-    // Try to fix the file
-    fixed, err := v.fixSingleFile(path)
+    newContent, changed, err := rule.Fix(filePath, content, cfg)
     if err != nil {
-      return fmt.Errorf("failed to fix %s: %w", path, err)
+      return nil, false, fmt.Errorf("failed to apply fixer to %s: %w", filePath, err)
     }
-
-    if fixed {
-      fixedFiles = append(fixedFiles, path)
+    if changed {
+      content = newContent
+      modified = true
     }
+  }
 
-    return nil
-  })
-
-  return fixedFiles, walkErr
+  return content, modified, nil
 }
 
 func (v *Validator) checkForEditorConfig(directory string) error {
   // If using custom config file, check that it exists
   if v.config.CustomConfigPath != "" {
-    if _, err := os.Stat(v.config.CustomConfigPath); err != nil {
+    if _, err := fs.Stat(v.fsys, v.config.CustomConfigPath); err != nil {
       return fmt.Errorf("custom config file not found: %s", v.config.CustomConfigPath)
     }
     return nil
   }
 
   // Original logic for hierarchical search
-  absDir, err := filepath.Abs(directory)
+  absDir, err := v.lookupPath(directory)
   if err != nil {
     return fmt.Errorf("failed to get absolute path: %w", err)
   }
 
   dir := absDir
   for {
-    configPath := filepath.Join(dir, ".editorconfig")
+    configPath := v.joinPath(dir, ".editorconfig")
 
-    if _, err := os.Stat(configPath); err == nil {
+    if _, err := fs.Stat(v.fsys, configPath); err == nil {
       return nil // Found .editorconfig
     }
 
-    parent := filepath.Dir(dir)
+    parent := v.parentDir(dir)
     if parent == dir {
       // Reached filesystem root
       break
@@ -511,7 +456,7 @@ func (v *Validator) checkForEditorConfig(directory string) error {
 }
 
 // isBinaryFile checks if a file should be skipped (binary or executable)
-func isBinaryFile(filePath string, info os.FileInfo) bool {
+func isBinaryFile(fsys fs.FS, filePath string, info fs.FileInfo) bool {
   ext := strings.ToLower(filepath.Ext(filePath))
 
   // Skip executable files with no extension
@@ -539,7 +484,7 @@ func isBinaryFile(filePath string, info os.FileInfo) bool {
   }
 
   // If it has no extension or unknown extension, check for null bytes
-  file, err := os.Open(filePath)
+  file, err := fsys.Open(filePath)
   if err != nil {
     return true // If we can't read it, skip it
   }
@@ -561,24 +506,27 @@ func isBinaryFile(filePath string, info os.FileInfo) bool {
 // FileJob represents a file processing job
 type FileJob struct {
   Path string
-  Info os.FileInfo
+  Info fs.FileInfo
 }
 
-// validateFilesParallel validates files in parallel using worker goroutines
-func (v *Validator) validateFilesParallel(directory string) ([]rules.ValidationError, int, error) {
-  // Collect all files to process
-  files, err := v.collectFiles(directory)
-  if err != nil {
-    return nil, 0, err
+// checkFilesParallel validates or fixes files in parallel using worker
+// goroutines, passing each result to onFile as it completes. Results are
+// returned in the order of files. If ctx is cancelled, no further files are
+// started; files in progress are finished and returned with the others
+// processed, along with ctx.Err() if any files were left out. With FailFast
+// a file that cannot be processed cancels the rest in the same way.
+func (v *Validator) checkFilesParallel(ctx context.Context, files []FileJob, onFile func(*FileResult)) ([]FileResult, error) {
+  type indexedResult struct {
+    index  int
+    result FileResult
   }
 
-  if len(files) == 0 {
-    return []rules.ValidationError{}, 0, nil
-  }
+  ctx, cancel := context.WithCancel(ctx)
+  defer cancel()
 
   // Create channels for job distribution and result collection
-  jobs := make(chan FileJob, len(files))
-  results := make(chan []rules.ValidationError, len(files))
+  jobs := make(chan int, len(files))
+  results := make(chan indexedResult, len(files))
 
   // Start worker goroutines
   var wg sync.WaitGroup
@@ -586,78 +534,33 @@ func (v *Validator) validateFilesParallel(directory string) ([]rules.ValidationE
     wg.Add(1)
     go func() {
       defer wg.Done()
-      for job := range jobs {
-        errors := v.validateSingleFileSync(job.Path)
-        results <- errors
-      }
-    }()
-  }
-
-  // Send jobs to workers
-  go func() {
-    defer close(jobs)
-    for _, file := range files {
-      jobs <- file
-    }
-  }()
-
-  // Wait for all workers to complete
-  go func() {
-    wg.Wait()
-    close(results)
-  }()
-
-  // Collect results
-  var allErrors []rules.ValidationError
-  for errors := range results {
-    allErrors = append(allErrors, errors...)
-  }
-
-  return allErrors, len(files), nil
-}
-
-// fixFilesParallel fixes files in parallel using worker goroutines
-func (v *Validator) fixFilesParallel(directory string) ([]string, int, error) {
-  // Collect all files to process
-  files, err := v.collectFiles(directory)
-  if err != nil {
-    return nil, 0, err
-  }
-
-  if len(files) == 0 {
-    return []string{}, 0, nil
-  }
-
-  // Create channels for job distribution and result collection
-  jobs := make(chan FileJob, len(files))
-  results := make(chan string, len(files)) // Empty string means no fix needed
-
-  // Start worker goroutines
-  var wg sync.WaitGroup
-  for i := 0; i < v.workers; i++ {
-    wg.Add(1)
-    go func() {
-      defer wg.Done()
-      for job := range jobs {
-        fixed, err := v.fixSingleFile(job.Path)
-        if err != nil {
-          // Log error but continue processing other files
+      for index := range jobs {
+        // Drain the remaining jobs without starting them once cancelled
+        if ctx.Err() != nil {
           continue
         }
-        if fixed {
-          results <- job.Path
-        } else {
-          results <- "" // No fix needed
+
+        result := v.checkFile(files[index].Path)
+        if result.Err != nil && v.config.FailFast {
+          cancel()
         }
+        results <- indexedResult{index, result}
       }
     }()
   }
 
-  // Send jobs to workers
+  // Send jobs to workers until done or cancelled
   go func() {
     defer close(jobs)
-    for _, file := range files {
-      jobs <- file
+    for i := range files {
+      if ctx.Err() != nil {
+        return
+      }
+      select {
+      case <-ctx.Done():
+        return
+      case jobs <- i:
+      }
     }
   }()
 
@@ -668,79 +571,85 @@ func (v *Validator) fixFilesParallel(directory string) ([]string, int, error) {
   }()
 
   // Collect results
-  var fixedFiles []string
-  for result := range results {
-    if result != "" { // Non-empty means file was fixed
-      fixedFiles = append(fixedFiles, result)
+  done := make([]bool, len(files))
+  ordered := make([]FileResult, len(files))
+  for r := range results {
+    onFile(&r.result)
+    done[r.index] = true
+    ordered[r.index] = r.result
+  }
+
+  var checked []FileResult
+  for i, result := range ordered {
+    if done[i] {
+      checked = append(checked, result)
     }
   }
 
-  return fixedFiles, len(files), nil
+  if len(checked) == len(files) {
+    return checked, nil
+  }
+  return checked, ctx.Err()
 }
 
-// collectFiles gathers all files that should be processed
-func (v *Validator) collectFiles(directory string) ([]FileJob, error) {
+// collectFiles gathers all files that should be processed. The walk stops
+// with ctx.Err() if ctx is cancelled.
+func (v *Validator) collectFiles(ctx context.Context, directory string) ([]FileJob, error) {
   var files []FileJob
 
-  err := filepath.Walk(directory, func(path string, info os.FileInfo, walkErr error) error {
+  err := fs.WalkDir(v.fsys, directory, func(filePath string, entry fs.DirEntry, walkErr error) error {
+    if err := ctx.Err(); err != nil {
+      return err
+    }
     if walkErr != nil {
       return walkErr
     }
 
     // Skip directories
-    if info.IsDir() {
+    if entry.IsDir() {
       // Check if directory should be ignored
-      if v.shouldIgnore(path) {
-        return filepath.SkipDir
+      if v.shouldIgnore(filePath) {
+        return fs.SkipDir
       }
       // If not recursive, skip subdirectories
-      if !v.config.Recursive && path != directory {
-        return filepath.SkipDir
+      if !v.config.Recursive && filePath != directory {
+        return fs.SkipDir
       }
       return nil
     }
 
     // Skip .editorconfig files themselves
-    if info.Name() == ".editorconfig" {
+    if entry.Name() == ".editorconfig" {
       return nil
     }
 
     // Skip hidden files and directories
-    if strings.HasPrefix(info.Name(), ".") {
+    if strings.HasPrefix(entry.Name(), ".") {
       return nil
     }
 
     // Check if file should be ignored
-    if v.shouldIgnore(path) {
+    if v.shouldIgnore(filePath) {
       return nil
     }
 
+    info, err := entry.Info()
+    if err != nil {
+      return err
+    }
+
     // Skip binary files and executable files
-    if isBinaryFile(path, info) {
+    if isBinaryFile(v.fsys, filePath, info) {
       return nil
     }
 
-    files = append(files, FileJob{Path: path, Info: info})
+    files = append(files, FileJob{Path: filePath, Info: info})
     return nil
   })
 
   return files, err
 }
 
-// validateSingleFileSync performs synchronous validation of a single file
-func (v *Validator) validateSingleFileSync(filePath string) []rules.ValidationError {
-  errors, err := v.validateSingleFileErrors(filePath)
-  if err != nil {
-    // Convert error to ValidationError for consistent handling
-    return []rules.ValidationError{{
-      FilePath: filePath,
-      Rule:     "file_access",
-      Message:  err.Error(),
-    }}
-  }
-  return errors
-}
-
 // shouldIgnore checks if a file path should be ignored based on ignore patterns
 func (v *Validator) shouldIgnore(filePath string) bool {
   if len(v.config.ExcludePatterns) == 0 {
@@ -751,24 +660,16 @@ func (v *Validator) shouldIgnore(filePath string) bool {
   normalizedPath := filepath.ToSlash(filePath)
 
   for _, pattern := range v.config.ExcludePatterns {
-    // Convert glob pattern to regex for more powerful matching
-    regexPattern, err := config.ConvertPatternToRegex(pattern)
+    glob, err := config.CompileGlob(pattern)
     if err != nil {
       continue // Skip invalid patterns
     }
 
-    // Check if the path matches the regex pattern
-    matched, err := regexp.MatchString(regexPattern, normalizedPath)
-    if err == nil && matched {
-      return true
-    }
-
-    // Also check relative paths (remove leading directories)
+    // Check the path and every suffix of it, so patterns containing a
+    // separator can match below any directory
     pathParts := strings.Split(normalizedPath, "/")
     for i := 0; i < len(pathParts); i++ {
-      relativePath := strings.Join(pathParts[i:], "/")
-      matched, err := regexp.MatchString(regexPattern, relativePath)
-      if err == nil && matched {
+      if glob.Match(strings.Join(pathParts[i:], "/")) {
         return true
       }
     }
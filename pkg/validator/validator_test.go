@@ -0,0 +1,558 @@
+package validator
+
+import (
+  "context"
+  "errors"
+  "io/fs"
+  "os"
+  "path/filepath"
+  "sync"
+  "testing"
+  "testing/fstest"
+)
+
+// writeTree creates files under a temporary directory and returns its path
+func writeTree(t *testing.T, files map[string]string) string {
+  t.Helper()
+  dir := t.TempDir()
+  for name, content := range files {
+    if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+      t.Fatal(err)
+    }
+  }
+  return dir
+}
+
+const lfConfig = "root = true\n\n[*]\nend_of_line = lf\n"
+
+func TestCheck(t *testing.T) {
+  dir := writeTree(t, map[string]string{
+    ".editorconfig": lfConfig,
+    "a.txt":         "a\r\nb\r\n",
+    "b.txt":         "a\nb\n",
+  })
+
+  var seen []string
+  v := New(Config{OnFile: func(file FileResult) { seen = append(seen, file.Path) }})
+
+  report, err := v.Check(context.Background(), []string{dir})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if report.Mode != "validate" {
+    t.Errorf("Mode = %q, want validate", report.Mode)
+  }
+  if len(report.Files) != 2 {
+    t.Fatalf("got %d files, want 2", len(report.Files))
+  }
+  if len(seen) != 2 {
+    t.Errorf("OnFile called %d times, want 2", len(seen))
+  }
+
+  tests := []struct {
+    path   string
+    errors int
+  }{
+    {filepath.Join(dir, "a.txt"), 2},
+    {filepath.Join(dir, "b.txt"), 0},
+  }
+  for i, tt := range tests {
+    file := report.Files[i]
+    if file.Path != tt.path {
+      t.Errorf("Files[%d].Path = %s, want %s", i, file.Path, tt.path)
+    }
+    if len(file.Errors) != tt.errors {
+      t.Errorf("%s: got %d errors, want %d", file.Path, len(file.Errors), tt.errors)
+    }
+    if file.Config == nil || file.Config.EndOfLine != "lf" {
+      t.Errorf("%s: resolved config = %+v, want end_of_line lf", file.Path, file.Config)
+    }
+  }
+
+  result := report.Result()
+  if result.Success || result.Violations != 2 || len(result.Errors) != 2 || result.TotalFiles != 2 {
+    t.Errorf("Result() = %+v", result)
+  }
+}
+
+func TestCheckDiscardErrors(t *testing.T) {
+  dir := writeTree(t, map[string]string{
+    ".editorconfig": lfConfig,
+    "a.txt":         "a\r\nb\r\n",
+    "b.txt":         "a\r\n",
+  })
+
+  streamed := 0
+  v := New(Config{
+    OnFile:        func(file FileResult) { streamed += len(file.Errors) },
+    DiscardErrors: true,
+  })
+
+  report, err := v.Check(context.Background(), []string{dir})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // OnFile received the violations, but only their count was kept
+  if streamed != 3 {
+    t.Errorf("OnFile received %d errors, want 3", streamed)
+  }
+  for _, file := range report.Files {
+    if file.Errors != nil {
+      t.Errorf("%s: errors were kept: %v", file.Path, file.Errors)
+    }
+  }
+
+  result := report.Result()
+  if result.Success || result.Violations != 3 || len(result.Errors) != 0 {
+    t.Errorf("Result() = %+v, want 3 violations and no errors kept", result)
+  }
+}
+
+func TestCheckFix(t *testing.T) {
+  dir := writeTree(t, map[string]string{
+    ".editorconfig": lfConfig,
+    "a.txt":         "a\r\nb\r\n",
+    "b.txt":         "a\nb\n",
+  })
+
+  report, err := New(Config{Fix: true}).Check(context.Background(), []string{dir})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  fixed := report.FixedFiles()
+  if len(fixed) != 1 || fixed[0] != filepath.Join(dir, "a.txt") {
+    t.Errorf("FixedFiles() = %v", fixed)
+  }
+  if report.Success() {
+    t.Error("Success() = true, want false when files were fixed")
+  }
+
+  content, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if string(content) != "a\nb\n" {
+    t.Errorf("fixed content = %q", content)
+  }
+}
+
+func TestCheckSingleFile(t *testing.T) {
+  dir := writeTree(t, map[string]string{
+    ".editorconfig": lfConfig,
+    "a.txt":         "a\r\n",
+  })
+  path := filepath.Join(dir, "a.txt")
+
+  report, err := New(Config{EnableRules: []string{"end_of_line"}}).Check(context.Background(), []string{path})
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if len(report.Files) != 1 || report.Files[0].Path != path {
+    t.Fatalf("Files = %+v", report.Files)
+  }
+  if len(report.Rules) != 1 || report.Rules[0].Name() != "end_of_line" {
+    t.Errorf("Rules = %v, want only end_of_line", report.Rules)
+  }
+  if got := len(report.Errors()); got != 1 {
+    t.Errorf("got %d errors, want 1", got)
+  }
+}
+
+func TestCheckErrors(t *testing.T) {
+  dir := writeTree(t, map[string]string{
+    ".editorconfig": lfConfig,
+    "a.txt":         "a\n",
+  })
+
+  cancelled, cancel := context.WithCancel(context.Background())
+  cancel()
+
+  tests := []struct {
+    name    string
+    ctx     context.Context
+    config  Config
+    targets []string
+    want    error
+  }{
+    {"missing target", context.Background(), Config{}, []string{filepath.Join(dir, "missing")}, os.ErrNotExist},
+    {"unknown rule", context.Background(), Config{EnableRules: []string{"no_such_rule"}}, []string{dir}, nil},
+    {"cancelled", cancelled, Config{}, []string{dir}, context.Canceled},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      _, err := New(tt.config).Check(tt.ctx, tt.targets)
+      if err == nil {
+        t.Fatal("expected an error")
+      }
+      if tt.want != nil && !errors.Is(err, tt.want) {
+        t.Errorf("error = %v, want %v", err, tt.want)
+      }
+    })
+  }
+}
+
+func TestValidateContent(t *testing.T) {
+  dir := writeTree(t, map[string]string{
+    ".editorconfig": lfConfig + "\n[*.md]\nend_of_line = crlf\n",
+  })
+
+  tests := []struct {
+    name    string
+    path    string
+    content string
+    errors  int
+    fixed   string
+  }{
+    {"crlf in lf file", "gen/a.txt", "a\r\nb\r\n", 2, "a\nb\n"},
+    {"lf in lf file", "gen/a.txt", "a\nb\n", 0, "a\nb\n"},
+    {"pattern for virtual path", "gen/a.md", "a\nb\n", 2, "a\r\nb\r\n"},
+  }
+
+  v := New(Config{EnableRules: []string{"end_of_line"}})
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      path := filepath.Join(dir, tt.path)
+      content := []byte(tt.content)
+
+      errs, err := v.ValidateContent(path, content)
+      if err != nil {
+        t.Fatal(err)
+      }
+      if len(errs) != tt.errors {
+        t.Errorf("got %d errors, want %d: %v", len(errs), tt.errors, errs)
+      }
+
+      fixed, changed, err := v.FixContent(path, content)
+      if err != nil {
+        t.Fatal(err)
+      }
+      if string(fixed) != tt.fixed || changed != (tt.errors > 0) {
+        t.Errorf("FixContent = %q, %v; want %q", fixed, changed, tt.fixed)
+      }
+      if string(content) != tt.content {
+        t.Errorf("input was modified to %q", content)
+      }
+    })
+  }
+
+  if _, err := os.Stat(filepath.Join(dir, "gen")); !os.IsNotExist(err) {
+    t.Errorf("virtual directory was created: %v", err)
+  }
+}
+
+func TestValidateContentConcurrent(t *testing.T) {
+  dir := writeTree(t, map[string]string{".editorconfig": lfConfig})
+  path := filepath.Join(dir, "a.txt")
+
+  // One Validator is safe to share; run with -race to check
+  v := New(Config{EnableRules: []string{"end_of_line"}})
+  var wg sync.WaitGroup
+  for i := 0; i < 8; i++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      errs, err := v.ValidateContent(path, []byte("a\r\n"))
+      if err != nil || len(errs) != 1 {
+        t.Errorf("ValidateContent = %v, %v; want 1 error", errs, err)
+      }
+      if _, _, err := v.FixContent(path, []byte("a\r\n")); err != nil {
+        t.Error(err)
+      }
+    }()
+  }
+  wg.Wait()
+}
+
+func TestValidateContentWithoutConfig(t *testing.T) {
+  v := New(Config{CustomConfigPath: filepath.Join(t.TempDir(), "missing")})
+  if _, err := v.ValidateContent("a.txt", []byte("a\n")); err == nil {
+    t.Error("expected an error when no EditorConfig applies")
+  }
+}
+
+// writableMapFS is an fstest.MapFS that fixed files can be written to
+type writableMapFS struct {
+  fstest.MapFS
+}
+
+func (m writableMapFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+  m.MapFS[name] = &fstest.MapFile{Data: data, Mode: perm}
+  return nil
+}
+
+func TestCheckFS(t *testing.T) {
+  newFS := func() fstest.MapFS {
+    return fstest.MapFS{
+      ".editorconfig":       {Data: []byte(lfConfig)},
+      "src/.editorconfig":   {Data: []byte("[*.bat]\nend_of_line = crlf\n")},
+      "src/a.txt":           {Data: []byte("a\r\n")},
+      "src/b.bat":           {Data: []byte("b\r\n")},
+      "src/deep/c.txt":      {Data: []byte("c\r\n")},
+      "src/image.bin":       {Data: []byte("\x00\x01")},
+      "custom.editorconfig": {Data: []byte("[*]\nend_of_line = crlf\n")},
+    }
+  }
+
+  tests := []struct {
+    name    string
+    config  Config
+    targets []string
+    files   []string
+    errors  int
+  }{
+    {"directory", Config{}, []string{"src"}, []string{"src/a.txt", "src/b.bat"}, 1},
+    {"recursive", Config{Recursive: true}, []string{"src"}, []string{"src/a.txt", "src/b.bat", "src/deep/c.txt"}, 2},
+    {"single file", Config{}, []string{"src/b.bat"}, []string{"src/b.bat"}, 0},
+    {"excluded", Config{ExcludePatterns: []string{"*.bat"}}, []string{"src"}, []string{"src/a.txt"}, 1},
+    {"custom config", Config{CustomConfigPath: "custom.editorconfig"}, []string{"src"}, []string{"src/a.txt", "src/b.bat"}, 0},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      tt.config.FS = newFS()
+      report, err := New(tt.config).Check(context.Background(), tt.targets)
+      if err != nil {
+        t.Fatal(err)
+      }
+
+      var files []string
+      for _, file := range report.Files {
+        files = append(files, file.Path)
+      }
+      if len(files) != len(tt.files) {
+        t.Fatalf("checked %v, want %v", files, tt.files)
+      }
+      for i := range files {
+        if files[i] != tt.files[i] {
+          t.Errorf("checked %v, want %v", files, tt.files)
+          break
+        }
+      }
+
+      if got := len(report.Errors()); got != tt.errors {
+        t.Errorf("got %d errors, want %d: %v", got, tt.errors, report.Errors())
+      }
+    })
+  }
+}
+
+func TestCheckFSFix(t *testing.T) {
+  fsys := fstest.MapFS{
+    ".editorconfig": {Data: []byte(lfConfig)},
+    "a.txt":         {Data: []byte("a\r\n")},
+  }
+
+  // A plain fs.FS is read-only, which fails the file rather than the Check
+  report, err := New(Config{FS: fsys, Fix: true}).Check(context.Background(), []string{"a.txt"})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if failed := report.FileErrors(); len(failed) != 1 || failed[0].Err == nil {
+    t.Errorf("FileErrors() = %+v, want a.txt to fail on a read-only file system", failed)
+  }
+  if report.Success() {
+    t.Error("Success() = true with a file that could not be fixed")
+  }
+
+  report, err = New(Config{FS: writableMapFS{fsys}, Fix: true}).Check(context.Background(), []string{"."})
+  if err != nil {
+    t.Fatal(err)
+  }
+  if fixed := report.FixedFiles(); len(fixed) != 1 || fixed[0] != "a.txt" {
+    t.Errorf("FixedFiles() = %v", fixed)
+  }
+  if got := string(fsys["a.txt"].Data); got != "a\n" {
+    t.Errorf("fixed content = %q", got)
+  }
+}
+
+func TestCheckFSWithoutEditorConfig(t *testing.T) {
+  fsys := fstest.MapFS{"a.txt": {Data: []byte("a\n")}}
+
+  if _, err := New(Config{FS: fsys}).Check(context.Background(), []string{"."}); err == nil {
+    t.Error("expected an error without an .editorconfig")
+  }
+}
+
+func TestCheckInterrupted(t *testing.T) {
+  fsys := fstest.MapFS{".editorconfig": {Data: []byte(lfConfig)}}
+  for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt", "e.txt", "f.txt"} {
+    fsys[name] = &fstest.MapFile{Data: []byte("x\r\n")}
+  }
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  // Cancel while the first file is being written
+  v := New(Config{FS: cancellingFS{writableMapFS{fsys}, cancel}, Fix: true, Workers: 1})
+  report, err := v.Check(ctx, []string{"."})
+  if !errors.Is(err, context.Canceled) {
+    t.Fatalf("error = %v, want context.Canceled", err)
+  }
+
+  if !report.Interrupted || !report.Result().Interrupted {
+    t.Error("report is not marked as interrupted")
+  }
+
+  // The file in progress was finished and no others were started
+  if fixed := report.FixedFiles(); len(report.Files) != 1 || len(fixed) != 1 || fixed[0] != "a.txt" {
+    t.Fatalf("got files %+v, want only a.txt fixed", report.Files)
+  }
+  for name, file := range fsys {
+    want := "x\r\n"
+    switch name {
+    case ".editorconfig":
+      continue
+    case "a.txt":
+      want = "x\n"
+    }
+    if string(file.Data) != want {
+      t.Errorf("%s = %q, want %q", name, file.Data, want)
+    }
+  }
+}
+
+func TestCheckFileErrors(t *testing.T) {
+  fsys := fstest.MapFS{".editorconfig": {Data: []byte(lfConfig)}}
+  for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+    fsys[name] = &fstest.MapFile{Data: []byte("x\r\n")}
+  }
+  failing := failingFS{writableMapFS{fsys}, "b.txt"}
+
+  tests := []struct {
+    name        string
+    config      Config
+    files       int
+    fixed       int
+    err         bool
+    interrupted bool
+  }{
+    {"validate keeps going", Config{FS: failing}, 4, 0, false, false},
+    {"fix keeps going", Config{FS: failing, Fix: true}, 4, 3, false, false},
+    {"fix fails fast", Config{FS: failing, Fix: true, FailFast: true}, 2, 1, true, true},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      for name := range fsys {
+        if name != ".editorconfig" {
+          fsys[name].Data = []byte("x\r\n")
+        }
+      }
+
+      var streamed []string
+      tt.config.Workers = 1
+      tt.config.OnFile = func(file FileResult) {
+        if file.Err != nil {
+          streamed = append(streamed, file.Path)
+        }
+      }
+
+      report, err := New(tt.config).Check(context.Background(), []string{"."})
+      if (err != nil) != tt.err {
+        t.Fatalf("error = %v, want error %v", err, tt.err)
+      }
+      if tt.err && !errors.Is(err, fs.ErrPermission) {
+        t.Errorf("error = %v, want the failing file's error", err)
+      }
+
+      if len(report.Files) != tt.files || len(report.FixedFiles()) != tt.fixed {
+        t.Errorf("got %d files with %d fixed, want %d with %d fixed", len(report.Files), len(report.FixedFiles()), tt.files, tt.fixed)
+      }
+      if report.Interrupted != tt.interrupted {
+        t.Errorf("Interrupted = %v, want %v", report.Interrupted, tt.interrupted)
+      }
+      if report.Success() {
+        t.Error("Success() = true with a file that could not be processed")
+      }
+
+      // The failure is reported once, as a file error rather than a violation
+      result := report.Result()
+      if len(result.FileErrors) != 1 || result.FileErrors[0].FilePath != "b.txt" {
+        t.Errorf("FileErrors = %+v, want only b.txt", result.FileErrors)
+      }
+      if len(streamed) != 1 || streamed[0] != "b.txt" {
+        t.Errorf("streamed failures = %v, want only b.txt", streamed)
+      }
+      for _, violation := range result.Errors {
+        if violation.FilePath == "b.txt" {
+          t.Errorf("unexpected violation for the failing file: %+v", violation)
+        }
+      }
+    })
+  }
+}
+
+// failingFS fails to read or write one file
+type failingFS struct {
+  writableMapFS
+  name string
+}
+
+func (f failingFS) ReadFile(name string) ([]byte, error) {
+  if name == f.name {
+    return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrPermission}
+  }
+  return f.writableMapFS.ReadFile(name)
+}
+
+func (f failingFS) Open(name string) (fs.File, error) {
+  if name == f.name {
+    return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+  }
+  return f.writableMapFS.Open(name)
+}
+
+func TestCheckInterruptedDuringDiscovery(t *testing.T) {
+  fsys := fstest.MapFS{
+    ".editorconfig": {Data: []byte(lfConfig)},
+    "a/b.txt":       {Data: []byte("x\r\n")},
+    "c/d.txt":       {Data: []byte("x\r\n")},
+  }
+
+  ctx, cancel := context.WithCancel(context.Background())
+  defer cancel()
+
+  // Cancel while the first directory is being listed
+  listed := 0
+  v := New(Config{FS: cancellingDirFS{fsys, cancel, &listed}, Recursive: true})
+  report, err := v.Check(ctx, []string{"."})
+  if !errors.Is(err, context.Canceled) {
+    t.Fatalf("error = %v, want context.Canceled", err)
+  }
+  if !report.Interrupted || len(report.Files) != 0 {
+    t.Errorf("got files %+v, interrupted %v; want none processed and interrupted", report.Files, report.Interrupted)
+  }
+  if listed != 1 {
+    t.Errorf("listed %d directories, want the walk to stop after 1", listed)
+  }
+}
+
+// cancellingDirFS cancels a context whenever a directory is listed
+type cancellingDirFS struct {
+  fstest.MapFS
+  cancel context.CancelFunc
+  listed *int
+}
+
+func (c cancellingDirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+  *c.listed++
+  c.cancel()
+  return c.MapFS.ReadDir(name)
+}
+
+// cancellingFS cancels a context whenever a file is written
+type cancellingFS struct {
+  writableMapFS
+  cancel context.CancelFunc
+}
+
+func (c cancellingFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+  c.cancel()
+  return c.writableMapFS.WriteFile(name, data, perm)
+}